@@ -2,19 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
+	"net/http"
 	"os/signal"
 	"sync"
 	"syscall"
-	"time"
 
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/env"
 )
 
-const ShutdownTimeout = 3 * time.Second
-
 func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
@@ -24,36 +23,42 @@ func main() {
 }
 
 func runMain(ctx context.Context) error {
-	e, c, err := env.Setup(ctx)
+	e, c, err := env.SetupGateway(ctx)
 	if err != nil {
-		return fmt.Errorf("setup.Setup: %w", err)
+		return fmt.Errorf("env SetupGateway: %w", err)
 	}
 
-	wg := sync.WaitGroup{}
-	wg.Add(1)
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
 
-	httpServer := e.APIGWHTTPServer
+	shutdownTimeout := e.Config.APIGWService.ShutdownTimeout
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
 
 	go func() {
+		defer wg.Done()
 		<-ctx.Done()
-		httpServer.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		e.Shutdown(ctx)
 	}()
 
 	go func() {
 		defer wg.Done()
+		defer stop()
 
 		slog.Info(fmt.Sprintf("api-gw http was started %s", e.Config.APIGWService.Addr))
-		if err := httpServer.ListenAndServe(); err != nil {
+		if err := e.HTTPServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			slog.Error("api-gw http server", slog.Any("err", err))
-			return
 		}
-
-		httpServer.Close()
 	}()
 
 	wg.Wait()
 
-	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
 	c.Close(ctx)
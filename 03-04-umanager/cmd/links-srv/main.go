@@ -9,13 +9,10 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
-	"time"
 
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/env"
 )
 
-const ShutdownTimeout = 3 * time.Second
-
 func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
@@ -25,30 +22,58 @@ func main() {
 }
 
 func runMain(ctx context.Context) error {
-	e, c, err := env.Setup(ctx)
+	e, c, err := env.SetupLinks(ctx)
 	if err != nil {
-		return fmt.Errorf("setup.Setup: %w", err)
+		return fmt.Errorf("env SetupLinks: %w", err)
 	}
 
-	wg := sync.WaitGroup{}
-	wg.Add(2)
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	shutdownTimeout := e.Config.LinksService.ShutdownTimeout
 
-	grpcServer := e.LinksGRPCServer
+	wg := sync.WaitGroup{}
+	wg.Add(6)
 
 	go func() {
+		defer wg.Done()
 		<-ctx.Done()
-		grpcServer.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		e.Shutdown(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		e.Health.Run(ctx)
 	}()
 
 	go func() {
 		defer wg.Done()
 		if err := e.LinkUpdater.Run(ctx); err != nil {
-			slog.Error("link updater Run: %w", err)
+			slog.Error("link updater Run", slog.Any("err", err))
 		}
 	}()
 
 	go func() {
 		defer wg.Done()
+		if err := e.LinkScheduler.Run(ctx); err != nil {
+			slog.Error("link scheduler Run", slog.Any("err", err))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		if err := e.OutboxRelay.Run(ctx); err != nil {
+			slog.Error("outbox relay Run", slog.Any("err", err))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer stop()
 
 		slog.Info(fmt.Sprintf("links grpc was started %s", e.Config.LinksService.GRPCServer.Addr))
 
@@ -58,15 +83,15 @@ func runMain(ctx context.Context) error {
 			return
 		}
 
-		if err := grpcServer.Serve(lis); err != nil {
-			slog.Error("net Listen", slog.Any("err", err))
+		if err := e.GRPCServer.Serve(lis); err != nil {
+			slog.Error("grpc Serve", slog.Any("err", err))
 			return
 		}
 	}()
 
 	wg.Wait()
 
-	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
 	c.Close(ctx)
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/env"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/link/stories/deadletters"
+)
+
+// runDeadLetters lists or replays the dead-lettered link messages, printing
+// them to stdout as JSON lines.
+func runDeadLetters(ctx context.Context, command string, args []string) error {
+	if command != "list" && command != "replay" {
+		return fmt.Errorf("unknown dlq command %q", command)
+	}
+
+	flags := flag.NewFlagSet("dlq "+command, flag.ExitOnError)
+	limit := flags.Int("limit", 100, "maximum number of messages")
+	_ = flags.Parse(args)
+
+	s, c, err := env.SetupDeadLetters(ctx)
+	if err != nil {
+		return fmt.Errorf("env SetupDeadLetters: %w", err)
+	}
+	defer c.Close(context.Background())
+
+	var messages []deadletters.Message
+	switch command {
+	case "list":
+		messages, err = s.List(*limit)
+	case "replay":
+		messages, err = s.Replay(*limit)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for _, m := range messages {
+		if err := enc.Encode(m); err != nil {
+			return fmt.Errorf("json Encode: %w", err)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("dlq %s: %w", command, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/env"
+)
+
+const usage = `usage:
+  umanager all                    run the users and links services and the api-gw
+                                  in one process for local development
+  umanager dlq list [-limit N]    print the dead-lettered link messages
+  umanager dlq replay [-limit N]  move the dead-lettered link messages back to
+                                  the queue of the link updater`
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var err error
+	switch {
+	case len(os.Args) == 2 && os.Args[1] == "all":
+		err = runMain(ctx)
+	case len(os.Args) >= 3 && os.Args[1] == "dlq":
+		err = runDeadLetters(ctx, os.Args[2], os.Args[3:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runMain(ctx context.Context) error {
+	e, c, err := env.Setup(ctx)
+	if err != nil {
+		return fmt.Errorf("env Setup: %w", err)
+	}
+
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	wg := sync.WaitGroup{}
+	wg.Add(9)
+
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+
+		// the gateway goes first, so that it does not call stopped services
+		shutdown(e.Gateway.Config.APIGWService.ShutdownTimeout, e.Gateway.Shutdown)
+		shutdown(e.Links.Config.LinksService.ShutdownTimeout, e.Links.Shutdown)
+		shutdown(e.Users.Config.UsersService.ShutdownTimeout, e.Users.Shutdown)
+	}()
+
+	go func() {
+		defer wg.Done()
+		e.Users.Health.Run(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		e.Links.Health.Run(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer stop()
+		serveGRPC("users", e.Users.GRPCServer, e.Users.Config.UsersService.GRPCServer.Addr)
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer stop()
+		serveGRPC("links", e.Links.GRPCServer, e.Links.Config.LinksService.GRPCServer.Addr)
+	}()
+
+	go func() {
+		defer wg.Done()
+		if err := e.Links.LinkUpdater.Run(ctx); err != nil {
+			slog.Error("link updater Run", slog.Any("err", err))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		if err := e.Links.LinkScheduler.Run(ctx); err != nil {
+			slog.Error("link scheduler Run", slog.Any("err", err))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		if err := e.Links.OutboxRelay.Run(ctx); err != nil {
+			slog.Error("outbox relay Run", slog.Any("err", err))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer stop()
+
+		slog.Info(fmt.Sprintf("api-gw http was started %s", e.Gateway.Config.APIGWService.Addr))
+		err := e.Gateway.HTTPServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("api-gw http server", slog.Any("err", err))
+		}
+	}()
+
+	wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.Gateway.Config.APIGWService.ShutdownTimeout)
+	defer cancel()
+
+	c.Close(ctx)
+
+	return nil
+}
+
+func shutdown(timeout time.Duration, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	fn(ctx)
+}
+
+func serveGRPC(name string, s *grpc.Server, addr string) {
+	slog.Info(fmt.Sprintf("%s grpc was started %s", name, addr))
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		slog.Error("net Listen", slog.String("service", name), slog.Any("err", err))
+		return
+	}
+
+	if err := s.Serve(lis); err != nil {
+		slog.Error("grpc Serve", slog.String("service", name), slog.Any("err", err))
+	}
+}
@@ -9,13 +9,10 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
-	"time"
 
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/env"
 )
 
-const ShutdownTimeout = 3 * time.Second
-
 func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
@@ -25,23 +22,38 @@ func main() {
 }
 
 func runMain(ctx context.Context) error {
-	e, c, err := env.Setup(ctx)
+	e, c, err := env.SetupUsers(ctx)
 	if err != nil {
-		return fmt.Errorf("setup.Setup: %w", err)
+		return fmt.Errorf("env SetupUsers: %w", err)
 	}
 
-	wg := sync.WaitGroup{}
-	wg.Add(1)
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
 
-	grpcServer := e.UsersGRPCServer
+	shutdownTimeout := e.Config.UsersService.ShutdownTimeout
+
+	wg := sync.WaitGroup{}
+	wg.Add(3)
 
 	go func() {
+		defer wg.Done()
 		<-ctx.Done()
-		grpcServer.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		e.Shutdown(ctx)
 	}()
 
 	go func() {
 		defer wg.Done()
+		e.Health.Run(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer stop()
+
 		slog.Info(fmt.Sprintf("users grpc was started %s", e.Config.UsersService.GRPCServer.Addr))
 
 		lis, err := net.Listen("tcp", e.Config.UsersService.GRPCServer.Addr)
@@ -50,15 +62,15 @@ func runMain(ctx context.Context) error {
 			return
 		}
 
-		if err := grpcServer.Serve(lis); err != nil {
-			slog.Error("net Listen", slog.Any("err", err))
+		if err := e.GRPCServer.Serve(lis); err != nil {
+			slog.Error("grpc Serve", slog.Any("err", err))
 			return
 		}
 	}()
 
 	wg.Wait()
 
-	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
 	c.Close(ctx)
@@ -5,21 +5,46 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/api/apiv1"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/httputil"
 )
 
-func Router(handler apiv1.ServerInterface) http.Handler {
+// BasePath is the prefix the operations of apiv1 are served under.
+const BasePath = "/api/v1"
+
+func Router(handler apiv1.ServerInterface, middlewares ...apiv1.MiddlewareFunc) chi.Router {
 	router := chi.NewRouter()
+	router.Use(requestID)
+	router.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		httputil.WriteError(w, r, http.StatusNotFound, "route not found")
+	})
+	router.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		httputil.WriteError(w, r, http.StatusMethodNotAllowed, "method not allowed")
+	})
 	router.Mount(
 		"/api", apiv1.HandlerWithOptions(
 			handler, apiv1.ChiServerOptions{
-				BaseURL: "/v1",
+				BaseURL:     "/v1",
+				Middlewares: middlewares,
 				ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-					slog.Error("handle error", slog.String("err", err.Error()))
+					slog.Info("handle error", slog.String("err", err.Error()))
+					httputil.WriteError(w, r, http.StatusBadRequest, err.Error())
 				},
 			},
 		),
 	)
 	return router
 }
+
+// requestID assigns an ID to every request, keeping the one sent by the client
+// in X-Request-Id, and returns it in the response header.
+func requestID(next http.Handler) http.Handler {
+	return middleware.RequestID(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(middleware.RequestIDHeader, middleware.GetReqID(r.Context()))
+			next.ServeHTTP(w, r)
+		}),
+	)
+}
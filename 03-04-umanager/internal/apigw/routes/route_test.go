@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/api/apiv1"
+)
+
+func TestRouterErrors(t *testing.T) {
+	router := Router(apiv1.Unimplemented{})
+
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		requestID string
+		status    int
+		code      apiv1.ErrorCode
+	}{
+		{
+			name:   "test_parameter_binding_error",
+			method: http.MethodGet,
+			target: "/api/v1/links?limit=abc",
+			status: http.StatusBadRequest,
+			code:   apiv1.BadRequest,
+		},
+		{
+			name:      "test_unknown_route",
+			method:    http.MethodGet,
+			target:    "/api/v1/unknown",
+			requestID: "req-42",
+			status:    http.StatusNotFound,
+			code:      apiv1.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.requestID != "" {
+				req.Header.Set("X-Request-Id", tt.requestID)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+
+			var got apiv1.Error
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("decode error body: %v", err)
+			}
+			if got.Code != tt.code {
+				t.Errorf("code = %s, want %s", got.Code, tt.code)
+			}
+			if got.RequestId == nil || *got.RequestId != rec.Header().Get("X-Request-Id") {
+				t.Errorf("request_id = %v, want %q", got.RequestId, rec.Header().Get("X-Request-Id"))
+			}
+			if tt.requestID != "" && *got.RequestId != tt.requestID {
+				t.Errorf("request_id = %s, want %s", *got.RequestId, tt.requestID)
+			}
+		})
+	}
+}
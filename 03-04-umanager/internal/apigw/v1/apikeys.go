@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/api/apiv1"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/httputil"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
+)
+
+func newAPIKeysHandler(usersClient usersClient) *apiKeysHandler {
+	return &apiKeysHandler{client: usersClient}
+}
+
+type apiKeysHandler struct {
+	client usersClient
+}
+
+func (h *apiKeysHandler) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
+	defer cancel()
+
+	keys, err := h.client.ListAPIKeys(ctx, &pb.Empty{})
+	if err != nil {
+		grpcError(w, r, err, "Cannot get API keys")
+		return
+	}
+
+	res := make([]apiv1.ApiKey, len(keys.ApiKeys))
+	for i, k := range keys.ApiKeys {
+		res[i] = apiKeyFromPB(k)
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		httputil.WriteError(w, r, http.StatusInternalServerError, "Cannot marshal API keys")
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	_, err = w.Write(b)
+	if err != nil {
+		slog.Error("GetApiKeys handler", slog.Any("err", err))
+	}
+}
+
+func (h *apiKeysHandler) PostApiKeys(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
+	defer cancel()
+
+	var keyReq apiv1.ApiKeyCreate
+	err := json.NewDecoder(r.Body).Decode(&keyReq)
+	if err != nil {
+		httputil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := &pb.CreateAPIKeyRequest{Name: keyReq.Name}
+	if keyReq.Scopes != nil {
+		for _, scope := range *keyReq.Scopes {
+			req.Scopes = append(req.Scopes, string(scope))
+		}
+	}
+	if keyReq.ExpiresAt != nil {
+		req.ExpiresAt = keyReq.ExpiresAt.Format(time.RFC3339)
+	}
+
+	created, err := h.client.CreateAPIKey(ctx, req)
+	if err != nil {
+		grpcError(w, r, err, "Cannot create API key")
+		return
+	}
+
+	b, err := json.Marshal(apiv1.ApiKeyCreated{ApiKey: apiKeyFromPB(created.ApiKey), Key: created.Key})
+	if err != nil {
+		httputil.WriteError(w, r, http.StatusInternalServerError, "Cannot marshal API key")
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	_, err = w.Write(b)
+	if err != nil {
+		slog.Error("PostApiKeys handler", slog.Any("err", err))
+	}
+}
+
+func (h *apiKeysHandler) DeleteApiKeysId(w http.ResponseWriter, r *http.Request, id string) {
+	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
+	defer cancel()
+
+	_, err := h.client.RevokeAPIKey(ctx, &pb.RevokeAPIKeyRequest{Id: id})
+	if err != nil {
+		grpcError(w, r, err, "Cannot revoke API key")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func apiKeyFromPB(k *pb.APIKey) apiv1.ApiKey {
+	res := apiv1.ApiKey{
+		Id:        k.Id,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    nonNil(k.Scopes),
+		CreatedAt: k.CreatedAt,
+	}
+	if k.ExpiresAt != "" {
+		res.ExpiresAt = &k.ExpiresAt
+	}
+	if k.LastUsedAt != "" {
+		res.LastUsedAt = &k.LastUsedAt
+	}
+	return res
+}
@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/api/apiv1"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/httputil"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
+)
+
+func newAuthHandler(usersClient usersClient) *authHandler {
+	return &authHandler{client: usersClient}
+}
+
+type authHandler struct {
+	client usersClient
+}
+
+func (h *authHandler) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
+	defer cancel()
+
+	var loginReq apiv1.LoginRequest
+	err := json.NewDecoder(r.Body).Decode(&loginReq)
+	if err != nil {
+		httputil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if loginReq.Username == "" || loginReq.Password == "" {
+		httputil.WriteError(w, r, http.StatusBadRequest, "bad request body")
+		return
+	}
+
+	tokens, err := h.client.Login(ctx, &pb.LoginRequest{Username: loginReq.Username, Password: loginReq.Password})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			httputil.WriteError(w, r, http.StatusUnauthorized, "Invalid username or password")
+			return
+		}
+		grpcError(w, r, err, "Cannot log in")
+		return
+	}
+
+	writeTokenPair(w, r, tokens)
+}
+
+func (h *authHandler) PostAuthRefresh(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
+	defer cancel()
+
+	var refreshReq apiv1.RefreshRequest
+	err := json.NewDecoder(r.Body).Decode(&refreshReq)
+	if err != nil {
+		httputil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if refreshReq.RefreshToken == "" {
+		httputil.WriteError(w, r, http.StatusBadRequest, "bad request body")
+		return
+	}
+
+	tokens, err := h.client.Refresh(ctx, &pb.RefreshRequest{RefreshToken: refreshReq.RefreshToken})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			httputil.WriteError(w, r, http.StatusUnauthorized, "Invalid refresh token")
+			return
+		}
+		grpcError(w, r, err, "Cannot refresh token")
+		return
+	}
+
+	writeTokenPair(w, r, tokens)
+}
+
+func (h *authHandler) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
+	defer cancel()
+
+	var logoutReq apiv1.RefreshRequest
+	err := json.NewDecoder(r.Body).Decode(&logoutReq)
+	if err != nil {
+		httputil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if logoutReq.RefreshToken == "" {
+		httputil.WriteError(w, r, http.StatusBadRequest, "bad request body")
+		return
+	}
+
+	_, err = h.client.Logout(ctx, &pb.LogoutRequest{RefreshToken: logoutReq.RefreshToken})
+	if err != nil {
+		grpcError(w, r, err, "Cannot log out")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeTokenPair(w http.ResponseWriter, r *http.Request, tokens *pb.TokenResponse) {
+	b, err := json.Marshal(apiv1.TokenPair{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    tokens.TokenType,
+		ExpiresIn:    tokens.ExpiresIn,
+	})
+	if err != nil {
+		httputil.WriteError(w, r, http.StatusInternalServerError, "Cannot marshal tokens")
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Cache-Control", "no-store")
+	_, err = w.Write(b)
+	if err != nil {
+		slog.Error("write token pair", slog.Any("err", err))
+	}
+}
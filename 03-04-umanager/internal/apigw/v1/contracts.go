@@ -1,6 +1,11 @@
 package v1
 
 import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
 )
 
@@ -11,3 +16,11 @@ type usersClient interface {
 type linksClient interface {
 	pb.LinkServiceClient
 }
+
+type apiKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, in *pb.VerifyAPIKeyRequest, opts ...grpc.CallOption) (*pb.VerifyAPIKeyResponse, error)
+}
+
+type tokenParser interface {
+	Parse(token string) (*auth.Claims, error)
+}
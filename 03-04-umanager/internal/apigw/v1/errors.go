@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/httputil"
+)
+
+// grpcError writes the HTTP counterpart of the gRPC status of err. Server
+// errors are logged and reported with the fallback message only.
+func grpcError(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	st := status.Convert(err)
+
+	code := httputil.ConvertGRPCCodeToHTTP(st.Code())
+	if code >= http.StatusInternalServerError && code != http.StatusServiceUnavailable &&
+		code != http.StatusGatewayTimeout {
+		slog.Error(fallback, slog.Any("err", err))
+		httputil.WriteError(w, r, http.StatusInternalServerError, fallback)
+		return
+	}
+
+	msg := st.Message()
+	if violations := fieldViolations(st); len(violations) > 0 {
+		msg = strings.Join(violations, "; ")
+	}
+
+	httputil.WriteError(w, r, code, msg)
+}
+
+func fieldViolations(st *status.Status) []string {
+	var res []string
+	for _, d := range st.Details() {
+		br, ok := d.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, v := range br.FieldViolations {
+			res = append(res, v.Field+": "+v.Description)
+		}
+	}
+
+	return res
+}
@@ -12,12 +12,16 @@ var _ serverInterface = (*Handler)(nil)
 
 func New(usersRepository usersClient, linksRepository linksClient) *Handler {
 	return &Handler{
-		usersHandler: newUsersHandler(usersRepository),
-		linksHandler: newLinksHandler(linksRepository),
+		authHandler:    newAuthHandler(usersRepository),
+		apiKeysHandler: newAPIKeysHandler(usersRepository),
+		usersHandler:   newUsersHandler(usersRepository),
+		linksHandler:   newLinksHandler(linksRepository),
 	}
 }
 
 type Handler struct {
+	*authHandler
+	*apiKeysHandler
 	*usersHandler
 	*linksHandler
 }
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/api/apiv1"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/httputil"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
 )
 
@@ -19,20 +22,66 @@ type linksHandler struct {
 	client linksClient
 }
 
-func (h *linksHandler) GetLinks(w http.ResponseWriter, r *http.Request) {
+func (h *linksHandler) GetLinks(w http.ResponseWriter, r *http.Request, params apiv1.GetLinksParams) {
 	// TODO implement me - implemented
+	caller := identity(r)
+	req := &pb.SearchLinksRequest{}
+	switch {
+	case params.UserId != nil:
+		req.UserId = *params.UserId
+	case !caller.Can(auth.PermissionLinksManage):
+		// without the manage permission only the own links are searched
+		req.UserId = caller.UserID
+	}
+	if !caller.CanAccess(req.UserId, auth.PermissionLinksManage) {
+		httputil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
 	defer cancel()
 
-	links, err := h.client.ListLinks(ctx, &pb.Empty{})
+	if params.Tag != nil {
+		req.Tags = *params.Tag
+	}
+	if params.Match != nil {
+		req.MatchAllTags = *params.Match == apiv1.All
+	}
+	if params.Q != nil {
+		req.Query = *params.Q
+	}
+	req.Broken = params.Broken
+	req.CreatedAfter = formatTime(params.CreatedAfter)
+	req.CreatedBefore = formatTime(params.CreatedBefore)
+	req.UpdatedAfter = formatTime(params.UpdatedAfter)
+	req.UpdatedBefore = formatTime(params.UpdatedBefore)
+	if params.Limit != nil {
+		req.PageSize = *params.Limit
+	}
+	if params.Cursor != nil {
+		req.PageToken = *params.Cursor
+	}
+	if params.Sort != nil {
+		req.Sort = string(*params.Sort)
+	}
+
+	links, err := h.client.SearchLinks(ctx, req)
 	if err != nil {
-		http.Error(w, "500 - Cannot get Links", http.StatusInternalServerError)
+		grpcError(w, r, err, "Cannot get Links")
 		return
 	}
 
-	b, err := json.Marshal(links)
+	res := apiv1.LinkPage{Links: make([]apiv1.Link, len(links.Links))}
+	for i, l := range links.Links {
+		res.Links[i] = linkFromPB(l)
+	}
+	if links.NextPageToken != "" {
+		res.NextCursor = &links.NextPageToken
+	}
+
+	b, err := json.Marshal(res)
 	if err != nil {
-		http.Error(w, "500 - Cannot marshal Links", http.StatusInternalServerError)
+		httputil.WriteError(w, r, http.StatusInternalServerError, "Cannot marshal Links")
 		return
 	}
 
@@ -51,27 +100,26 @@ func (h *linksHandler) PostLinks(w http.ResponseWriter, r *http.Request) {
 	var linkReq apiv1.LinkCreate
 	err := json.NewDecoder(r.Body).Decode(&linkReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httputil.WriteError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	if linkReq.Id != "" || linkReq.Url == "" {
-		http.Error(w, "bad request body", http.StatusBadRequest)
+	if linkReq.Id != nil {
+		httputil.WriteError(w, r, http.StatusBadRequest, "id: must not be set")
 		return
 	}
 
 	req := &pb.CreateLinkRequest{
-		Id:     linkReq.Id,
-		Images: linkReq.Images,
-		Tags:   linkReq.Tags,
-		Title:  linkReq.Title,
-		UserId: linkReq.UserId,
+		Images: valueOf(linkReq.Images),
+		Tags:   valueOf(linkReq.Tags),
+		Title:  valueOf(linkReq.Title),
+		UserId: identity(r).UserID,
 		Url:    linkReq.Url,
 	}
 
 	_, err = h.client.CreateLink(ctx, req)
 	if err != nil {
-		http.Error(w, "500 - Cannot create Link", http.StatusInternalServerError)
+		grpcError(w, r, err, "Cannot create Link")
 		return
 	}
 
@@ -83,18 +131,9 @@ func (h *linksHandler) DeleteLinksId(w http.ResponseWriter, r *http.Request, id
 	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
 	defer cancel()
 
-	req := &pb.GetLinkRequest{Id: r.PathValue("id")}
-
-	_, err := h.client.GetLink(ctx, req)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("404 - Link with ID %s is not found", r.PathValue("id")), http.StatusNotFound)
-		return
-	}
-
-	delReq := &pb.DeleteLinkRequest{Id: r.PathValue("id")}
-	_, err = h.client.DeleteLink(ctx, delReq)
+	_, err := h.client.DeleteLink(ctx, &pb.DeleteLinkRequest{Id: id})
 	if err != nil {
-		http.Error(w, "500 - Cannot create Link", http.StatusInternalServerError)
+		grpcError(w, r, err, "Cannot delete Link")
 		return
 	}
 
@@ -106,17 +145,16 @@ func (h *linksHandler) GetLinksId(w http.ResponseWriter, r *http.Request, id str
 	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
 	defer cancel()
 
-	req := &pb.GetLinkRequest{Id: r.PathValue("id")}
-
-	link, err := h.client.GetLink(ctx, req)
+	link, err := h.client.GetLink(ctx, &pb.GetLinkRequest{Id: id})
 	if err != nil {
-		http.Error(w, fmt.Sprintf("404 - Link with ID %s is not found", r.PathValue("id")), http.StatusNotFound)
+		grpcError(w, r, err, "Cannot get Link")
 		return
 	}
 
-	b, err := json.Marshal(link)
+	b, err := json.Marshal(linkFromPB(link))
 	if err != nil {
-		http.Error(w, "500 - Cannot marshal Link", http.StatusInternalServerError)
+		httputil.WriteError(w, r, http.StatusInternalServerError, "Cannot marshal Link")
+		return
 	}
 
 	w.Header().Add("Content-Type", "application/json")
@@ -134,49 +172,74 @@ func (h *linksHandler) PutLinksId(w http.ResponseWriter, r *http.Request, id str
 	var linkReq apiv1.LinkCreate
 	err := json.NewDecoder(r.Body).Decode(&linkReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httputil.WriteError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if linkReq.Id != nil && *linkReq.Id != id {
+		httputil.WriteError(w, r, http.StatusBadRequest, "id: must match the path")
 		return
 	}
 
 	updReq := &pb.UpdateLinkRequest{
-		Id:     linkReq.Id,
-		Title:  linkReq.Title,
+		Id:     id,
+		Title:  valueOf(linkReq.Title),
 		Url:    linkReq.Url,
-		Images: linkReq.Images,
-		Tags:   linkReq.Tags,
-		UserId: linkReq.UserId,
+		Images: valueOf(linkReq.Images),
+		Tags:   valueOf(linkReq.Tags),
 	}
 
 	_, err = h.client.UpdateLink(ctx, updReq)
 	if err != nil {
-		http.Error(w, "500 - Cannot update Link", http.StatusInternalServerError)
+		grpcError(w, r, err, "Cannot update Link")
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *linksHandler) PostLinksIdRefresh(w http.ResponseWriter, r *http.Request, id string) {
+	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
+	defer cancel()
+
+	_, err := h.client.RefreshLink(ctx, &pb.RefreshLinkRequest{Id: id})
+	if err != nil {
+		grpcError(w, r, err, "Cannot refresh Link")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
 func (h *linksHandler) GetLinksUserUserID(w http.ResponseWriter, r *http.Request, userID string) {
 	// TODO implement me - implemented
+	if !identity(r).CanAccess(userID, auth.PermissionLinksManage) {
+		httputil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
 	defer cancel()
 
-	req := &pb.GetLinksByUserId{UserId: r.PathValue("userID")}
-
-	links, err := h.client.GetLinkByUserID(ctx, req)
+	links, err := h.client.GetLinkByUserID(ctx, &pb.GetLinksByUserId{UserId: userID})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		grpcError(w, r, err, "Cannot get Links")
 		return
 	}
 
 	if len(links.Links) == 0 {
-		http.Error(w, fmt.Sprintf("404 - Links for user with ID %s are not found", r.PathValue("id")), http.StatusNotFound)
+		httputil.WriteError(w, r, http.StatusNotFound, fmt.Sprintf("Links for user with ID %s are not found", userID))
 		return
 	}
 
-	b, err := json.Marshal(links)
+	res := make([]apiv1.Link, len(links.Links))
+	for i, l := range links.Links {
+		res[i] = linkFromPB(l)
+	}
+
+	b, err := json.Marshal(res)
 	if err != nil {
-		http.Error(w, "500 - Cannot marshal Links", http.StatusInternalServerError)
+		httputil.WriteError(w, r, http.StatusInternalServerError, "Cannot marshal Links")
 		return
 	}
 
@@ -186,3 +249,74 @@ func (h *linksHandler) GetLinksUserUserID(w http.ResponseWriter, r *http.Request
 		slog.Error("GetLinksUserUserID handler", slog.Any("err", err))
 	}
 }
+
+func linkFromPB(l *pb.Link) apiv1.Link {
+	return apiv1.Link{
+		Id:         l.Id,
+		Title:      l.Title,
+		Url:        l.Url,
+		Images:     nonNil(l.Images),
+		Tags:       nonNil(l.Tags),
+		UserId:     l.UserId,
+		CreatedAt:  l.CreatedAt,
+		UpdatedAt:  l.UpdatedAt,
+		Enrichment: enrichmentFromPB(l.Enrichment),
+	}
+}
+
+func enrichmentFromPB(e *pb.Enrichment) apiv1.Enrichment {
+	res := apiv1.Enrichment{
+		State:               apiv1.EnrichmentState(e.GetState()),
+		Attempts:            e.GetAttempts(),
+		ConsecutiveFailures: e.GetConsecutiveFailures(),
+		Broken:              e.GetBroken(),
+	}
+	if res.State == "" {
+		res.State = apiv1.Pending
+	}
+	if t, err := time.Parse(time.RFC3339, e.GetLastAttemptAt()); err == nil {
+		res.LastAttemptAt = &t
+	}
+	if e.GetHttpStatus() != 0 {
+		status := e.GetHttpStatus()
+		res.HttpStatus = &status
+	}
+	if e.GetLastError() != "" {
+		lastError := e.GetLastError()
+		res.LastError = &lastError
+	}
+	if e.GetRedirectUrl() != "" {
+		redirectURL := e.GetRedirectUrl()
+		res.RedirectUrl = &redirectURL
+	}
+
+	return res
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+// valueOf returns the value of an optional field or its zero value.
+func valueOf[T any](p *T) T {
+	var v T
+	if p != nil {
+		v = *p
+	}
+
+	return v
+}
+
+// nonNil makes empty repeated fields marshal as [] rather than null, as the
+// spec requires arrays.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
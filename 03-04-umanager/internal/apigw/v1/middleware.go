@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/api/apiv1"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/httputil"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
+)
+
+// Authenticate checks the access token or API key of every operation that
+// declares a security scheme. The caller is stored in the request context and
+// appended to the metadata of the gRPC calls made by the handlers.
+func Authenticate(parser tokenParser, keys apiKeyVerifier) apiv1.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.Context().Value(apiv1.BearerAuthScopes).([]string); !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token := r.Header.Get("X-API-Key")
+			if token == "" {
+				token, _ = bearerToken(r)
+			}
+			if token == "" {
+				w.Header().Set("WWW-Authenticate", auth.TokenType)
+				httputil.WriteError(w, r, http.StatusUnauthorized, "Missing access token")
+				return
+			}
+
+			var (
+				id  auth.Identity
+				err error
+			)
+			if auth.IsAPIKey(token) {
+				id, err = verifyAPIKey(r.Context(), keys, token)
+			} else {
+				id, err = parseAccessToken(parser, token)
+			}
+			if err != nil {
+				slog.Info("authenticate request", slog.Any("err", err))
+				w.Header().Set("WWW-Authenticate", auth.TokenType)
+				httputil.WriteError(w, r, http.StatusUnauthorized, "Invalid access token")
+				return
+			}
+
+			ctx := auth.WithIdentity(r.Context(), id)
+			ctx = auth.NewOutgoingContext(ctx, id)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func parseAccessToken(parser tokenParser, token string) (auth.Identity, error) {
+	claims, err := parser.Parse(token)
+	if err != nil {
+		return auth.Identity{}, err
+	}
+
+	return auth.Identity{UserID: claims.Subject, Roles: claims.Roles, Permissions: claims.Permissions}, nil
+}
+
+func verifyAPIKey(ctx context.Context, keys apiKeyVerifier, key string) (auth.Identity, error) {
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	res, err := keys.VerifyAPIKey(ctx, &pb.VerifyAPIKeyRequest{Key: key})
+	if err != nil {
+		return auth.Identity{}, fmt.Errorf("users client VerifyAPIKey: %w", err)
+	}
+
+	return auth.Identity{UserID: res.UserId, Roles: res.Roles, Permissions: res.Permissions, APIKey: true}, nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, auth.TokenType) || token == "" {
+		return "", false
+	}
+
+	return token, true
+}
+
+// identity returns the caller set by Authenticate.
+func identity(r *http.Request) auth.Identity {
+	id, _ := auth.IdentityFromContext(r.Context())
+	return id
+}
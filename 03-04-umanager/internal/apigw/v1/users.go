@@ -3,11 +3,13 @@ package v1
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/api/apiv1"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/httputil"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
 )
 
@@ -23,20 +25,44 @@ type usersHandler struct {
 	client usersClient
 }
 
-func (h *usersHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+func (h *usersHandler) GetUsers(w http.ResponseWriter, r *http.Request, params apiv1.GetUsersParams) {
 	// TODO implement me - implemented
+	if !identity(r).Can(auth.PermissionUsersManage) {
+		httputil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
 	defer cancel()
 
-	users, err := h.client.ListUsers(ctx, &pb.Empty{})
+	req := &pb.ListUsersRequest{}
+	if params.Limit != nil {
+		req.PageSize = *params.Limit
+	}
+	if params.Cursor != nil {
+		req.PageToken = *params.Cursor
+	}
+	if params.Sort != nil {
+		req.Sort = string(*params.Sort)
+	}
+
+	users, err := h.client.ListUsers(ctx, req)
 	if err != nil {
-		http.Error(w, "500 - Cannot get Users", http.StatusInternalServerError)
+		grpcError(w, r, err, "Cannot get Users")
 		return
 	}
 
-	b, err := json.Marshal(users)
+	res := apiv1.UserPage{Users: make([]apiv1.User, len(users.Users))}
+	for i, u := range users.Users {
+		res.Users[i] = userFromPB(u)
+	}
+	if users.NextPageToken != "" {
+		res.NextCursor = &users.NextPageToken
+	}
+
+	b, err := json.Marshal(res)
 	if err != nil {
-		http.Error(w, "500 - Cannot marshal Users", http.StatusInternalServerError)
+		httputil.WriteError(w, r, http.StatusInternalServerError, "Cannot marshal Users")
 		return
 	}
 
@@ -52,21 +78,16 @@ func (h *usersHandler) PostUsers(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
 	defer cancel()
 
-	var userReq pb.CreateUserRequest
+	var userReq apiv1.UserCreate
 	err := json.NewDecoder(r.Body).Decode(&userReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httputil.WriteError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	if userReq.Id != "" || userReq.Username == "" || userReq.Password == "" {
-		http.Error(w, "bad request body", http.StatusBadRequest)
-		return
-	}
-
-	_, err = h.client.CreateUser(ctx, &userReq)
+	_, err = h.client.CreateUser(ctx, &pb.CreateUserRequest{Username: userReq.Username, Password: userReq.Password})
 	if err != nil {
-		http.Error(w, "500 - Cannot create User", http.StatusInternalServerError)
+		grpcError(w, r, err, "Cannot create User")
 		return
 	}
 
@@ -75,21 +96,17 @@ func (h *usersHandler) PostUsers(w http.ResponseWriter, r *http.Request) {
 
 func (h *usersHandler) DeleteUsersId(w http.ResponseWriter, r *http.Request, id string) {
 	// TODO implement me - implemented
-	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
-	defer cancel()
-
-	req := &pb.GetUserRequest{Id: r.PathValue("id")}
-
-	_, err := h.client.GetUser(ctx, req)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("404 - User with ID %s is not found", r.PathValue("id")), http.StatusNotFound)
+	if !identity(r).CanAccess(id, auth.PermissionUsersManage) {
+		httputil.WriteError(w, r, http.StatusForbidden, "Forbidden")
 		return
 	}
 
-	delReq := &pb.DeleteUserRequest{Id: r.PathValue("id")}
-	_, err = h.client.DeleteUser(ctx, delReq)
+	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
+	defer cancel()
+
+	_, err := h.client.DeleteUser(ctx, &pb.DeleteUserRequest{Id: id})
 	if err != nil {
-		http.Error(w, "500 - Cannot create User", http.StatusInternalServerError)
+		grpcError(w, r, err, "Cannot delete User")
 		return
 	}
 
@@ -98,20 +115,24 @@ func (h *usersHandler) DeleteUsersId(w http.ResponseWriter, r *http.Request, id
 
 func (h *usersHandler) GetUsersId(w http.ResponseWriter, r *http.Request, id string) {
 	// TODO implement me - implemented
+	if !identity(r).CanAccess(id, auth.PermissionUsersManage) {
+		httputil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
 	defer cancel()
 
-	req := &pb.GetUserRequest{Id: r.PathValue("id")}
-
-	user, err := h.client.GetUser(ctx, req)
+	user, err := h.client.GetUser(ctx, &pb.GetUserRequest{Id: id})
 	if err != nil {
-		http.Error(w, fmt.Sprintf("404 - User with ID %s is not found", r.PathValue("id")), http.StatusNotFound)
+		grpcError(w, r, err, "Cannot get User")
 		return
 	}
 
-	b, err := json.Marshal(user)
+	b, err := json.Marshal(userFromPB(user))
 	if err != nil {
-		http.Error(w, "500 - Cannot marshal User", http.StatusInternalServerError)
+		httputil.WriteError(w, r, http.StatusInternalServerError, "Cannot marshal User")
+		return
 	}
 
 	w.Header().Add("Content-Type", "application/json")
@@ -123,36 +144,50 @@ func (h *usersHandler) GetUsersId(w http.ResponseWriter, r *http.Request, id str
 
 func (h *usersHandler) PutUsersId(w http.ResponseWriter, r *http.Request, id string) {
 	// TODO implement me - implemented
+	if !identity(r).CanAccess(id, auth.PermissionUsersManage) {
+		httputil.WriteError(w, r, http.StatusForbidden, "Forbidden")
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), ctxTimeout)
 	defer cancel()
 
-	var userReq pb.UpdateUserRequest
+	var userReq apiv1.UserUpdate
 	err := json.NewDecoder(r.Body).Decode(&userReq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httputil.WriteError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	req := &pb.GetUserRequest{Id: r.PathValue("id")}
-
-	user, err := h.client.GetUser(ctx, req)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("404 - User with ID %s is not found", r.PathValue("id")), http.StatusNotFound)
+	if userReq.Id != nil && userReq.Id.String() != id {
+		httputil.WriteError(w, r, http.StatusBadRequest, "id: must match the path")
 		return
 	}
 
-	updReq := &pb.CreateUserRequest{Id: user.Id}
-	if user.Username != userReq.Username {
-		updReq.Username = userReq.Username
+	user, err := h.client.GetUser(ctx, &pb.GetUserRequest{Id: id})
+	if err != nil {
+		grpcError(w, r, err, "Cannot get User")
+		return
 	}
 
-	if user.Password != userReq.Password {
-		updReq.Password = userReq.Password
+	updReq := &pb.UpdateUserRequest{Id: user.Id, Password: valueOf(userReq.Password), Roles: valueOf(userReq.Roles)}
+	if username := valueOf(userReq.Username); user.Username != username {
+		updReq.Username = username
 	}
 
-	_, err = h.client.CreateUser(ctx, updReq)
+	_, err = h.client.UpdateUser(ctx, updReq)
 	if err != nil {
-		http.Error(w, "500 - Cannot update User", http.StatusInternalServerError)
+		grpcError(w, r, err, "Cannot update User")
 		return
 	}
 }
+
+func userFromPB(u *pb.User) apiv1.User {
+	return apiv1.User{
+		Id:        u.Id,
+		Username:  u.Username,
+		Roles:     nonNil(u.Roles),
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/api/apiv1"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/httputil"
+)
+
+type ValidationOptions struct {
+	// BasePath is the prefix the paths of the spec are served under.
+	BasePath string
+	// ValidateResponses checks responses against the spec too and replaces
+	// the ones that do not match it with 500. It is meant for tests.
+	ValidateResponses bool
+}
+
+var defineFormats sync.Once
+
+// Validate validates requests against the embedded OpenAPI spec: parameters,
+// required fields, formats, lengths and unknown fields of request bodies.
+// Requests that do not match are rejected with 400 before reaching handlers.
+func Validate(opts ValidationOptions) (apiv1.MiddlewareFunc, error) {
+	defineFormats.Do(func() {
+		openapi3.DefineStringFormat("uuid", openapi3.FormatOfStringForUUIDOfRFC4122)
+		openapi3.DefineStringFormatCallback("uri", validateURI)
+	})
+
+	spec, err := apiv1.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("apiv1 GetSwagger: %w", err)
+	}
+	spec.Servers = nil
+
+	router, err := legacy.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("legacy NewRouter: %w", err)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, httputil.MaxBodyBytes)
+			}
+
+			find := r.Clone(r.Context())
+			find.URL.Path = strings.TrimPrefix(r.URL.Path, opts.BasePath)
+
+			route, pathParams, err := router.FindRoute(find)
+			if err != nil {
+				// the generated router has matched the request, so the spec must have the route
+				slog.Error("validate request: find route", slog.String("path", r.URL.Path), slog.Any("err", err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+					MultiError:         true,
+				},
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				status := http.StatusBadRequest
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					status = http.StatusRequestEntityTooLarge
+				}
+				httputil.WriteError(w, r, status, strings.Join(violations("", err), "; "))
+				return
+			}
+
+			if !opts.ValidateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &responseRecorder{header: http.Header{}}
+			next.ServeHTTP(rec, r)
+
+			resInput := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 rec.statusCode(),
+				Header:                 rec.header,
+				Options:                &openapi3filter.Options{MultiError: true},
+			}
+			resInput.SetBodyBytes(rec.body.Bytes())
+			if err := openapi3filter.ValidateResponse(r.Context(), resInput); err != nil {
+				msg := strings.Join(violations("response", err), "; ")
+				slog.Error("response does not match the spec", slog.String("path", r.URL.Path), slog.String("err", msg))
+				httputil.WriteError(w, r, http.StatusInternalServerError, "response does not match the spec: "+msg)
+				return
+			}
+
+			rec.flush(w)
+		})
+	}, nil
+}
+
+// violations flattens a validation error into "field: reason" messages.
+func violations(field string, err error) []string {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var res []string
+		for _, err := range e {
+			res = append(res, violations(field, err)...)
+		}
+		return res
+	case *openapi3filter.RequestError:
+		switch {
+		case e.Parameter != nil:
+			field = e.Parameter.Name
+		case e.RequestBody != nil:
+			field = "body"
+		}
+		if e.Err == nil {
+			return []string{withField(field, e.Reason)}
+		}
+		return violations(field, e.Err)
+	case *openapi3filter.ResponseError:
+		if e.Err == nil {
+			return []string{withField(field, e.Reason)}
+		}
+		return violations(field, e.Err)
+	case *openapi3.SchemaError:
+		if pointer := e.JSONPointer(); len(pointer) > 0 {
+			field = strings.TrimPrefix(field+"."+strings.Join(pointer, "."), ".")
+		}
+		return []string{withField(field, e.Reason)}
+	}
+
+	return []string{withField(field, err.Error())}
+}
+
+func withField(field, reason string) string {
+	if field == "" {
+		return reason
+	}
+
+	return field + ": " + reason
+}
+
+// validateURI accepts absolute http and https URLs.
+func validateURI(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("must be an absolute http or https URL")
+	}
+
+	return nil
+}
+
+// responseRecorder keeps the response until it is validated.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+
+	return r.status
+}
+
+func (r *responseRecorder) flush(w http.ResponseWriter) {
+	for k, v := range r.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(r.statusCode())
+	_, err := w.Write(r.body.Bytes())
+	if err != nil {
+		slog.Error("write validated response", slog.Any("err", err))
+	}
+}
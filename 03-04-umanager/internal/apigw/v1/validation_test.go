@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/api/apiv1"
+)
+
+func TestValidate(t *testing.T) {
+	const userID = "8f5b1f3e-7c4a-4d2b-9a61-2f0c8e4b7d15"
+
+	validUser := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"` + userID + `","username":"alice","roles":[],` +
+			`"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}`))
+	}
+	created := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		next     http.HandlerFunc
+		status   int
+		called   bool
+		contains string
+	}{
+		{
+			name:   "test_valid_request",
+			method: http.MethodPost,
+			target: "/api/v1/links",
+			body:   `{"url":"https://example.com","tags":["go"]}`,
+			next:   created,
+			status: http.StatusCreated,
+			called: true,
+		},
+		{
+			name:     "test_unknown_field",
+			method:   http.MethodPost,
+			target:   "/api/v1/links",
+			body:     `{"url":"https://example.com","user_id":"someone"}`,
+			next:     created,
+			status:   http.StatusBadRequest,
+			contains: "user_id",
+		},
+		{
+			name:     "test_missing_required_field",
+			method:   http.MethodPost,
+			target:   "/api/v1/links",
+			body:     `{"title":"example"}`,
+			next:     created,
+			status:   http.StatusBadRequest,
+			contains: "url",
+		},
+		{
+			name:     "test_invalid_uri",
+			method:   http.MethodPost,
+			target:   "/api/v1/links",
+			body:     `{"url":"ftp:/example"}`,
+			next:     created,
+			status:   http.StatusBadRequest,
+			contains: "url",
+		},
+		{
+			name:     "test_too_large_body",
+			method:   http.MethodPost,
+			target:   "/api/v1/links",
+			body:     `{"url":"https://example.com","title":"` + strings.Repeat("a", 70_000) + `"}`,
+			next:     created,
+			status:   http.StatusRequestEntityTooLarge,
+			contains: "",
+		},
+		{
+			name:     "test_invalid_path_uuid",
+			method:   http.MethodGet,
+			target:   "/api/v1/users/abc",
+			next:     validUser,
+			status:   http.StatusBadRequest,
+			contains: "id",
+		},
+		{
+			name:   "test_valid_response",
+			method: http.MethodGet,
+			target: "/api/v1/users/" + userID,
+			next:   validUser,
+			status: http.StatusOK,
+			called: true,
+		},
+		{
+			name:   "test_response_drift",
+			method: http.MethodGet,
+			target: "/api/v1/users/" + userID,
+			next: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":"` + userID + `","username":"alice"}`))
+			},
+			status:   http.StatusInternalServerError,
+			called:   true,
+			contains: "response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validate, err := Validate(ValidationOptions{BasePath: "/api/v1", ValidateResponses: true})
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			var called bool
+			handler := validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				tt.next(w, r)
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d, body = %s", rec.Code, tt.status, rec.Body.String())
+			}
+			if called != tt.called {
+				t.Errorf("next called = %v, want %v", called, tt.called)
+			}
+			if rec.Code < http.StatusBadRequest {
+				return
+			}
+
+			var got apiv1.Error
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("decode error body: %v", err)
+			}
+			if got.Message == nil || !strings.Contains(*got.Message, tt.contains) {
+				t.Errorf("message = %v, want it to contain %q", got.Message, tt.contains)
+			}
+		})
+	}
+}
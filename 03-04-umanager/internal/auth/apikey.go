@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// APIKeyPrefix marks API keys so that they can be told apart from access tokens.
+const APIKeyPrefix = "umk_"
+
+// API key scopes.
+const (
+	ScopeLinksRead = "links:read"
+	ScopeFull      = "full"
+)
+
+var scopePermissions = map[string][]string{
+	ScopeLinksRead: {PermissionLinksRead},
+}
+
+// NewAPIKey returns a random API key, its short prefix shown to the user and
+// the hash under which it is stored.
+func NewAPIKey() (key, prefix, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", fmt.Errorf("rand Read: %w", err)
+	}
+
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	return key, key[:len(APIKeyPrefix)+6], HashAPIKey(key), nil
+}
+
+func HashAPIKey(key string) string {
+	return hashToken(key)
+}
+
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+func ValidScope(scope string) bool {
+	_, ok := scopePermissions[scope]
+	return ok || scope == ScopeFull
+}
+
+// ScopePermissions limits the permissions of the key owner to the key scopes.
+func ScopePermissions(scopes, permissions []string) []string {
+	if slices.Contains(scopes, ScopeFull) {
+		return permissions
+	}
+
+	var res []string
+	for _, scope := range scopes {
+		for _, p := range scopePermissions[scope] {
+			if slices.Contains(permissions, p) && !slices.Contains(res, p) {
+				res = append(res, p)
+			}
+		}
+	}
+
+	return res
+}
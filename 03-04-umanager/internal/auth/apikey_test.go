@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScopePermissions(t *testing.T) {
+	owner := []string{PermissionUsersRead, PermissionLinksRead, PermissionLinksWrite}
+
+	tests := []struct {
+		name        string
+		scopes      []string
+		permissions []string
+		expected    []string
+	}{
+		{
+			name:        "test_full_scope",
+			scopes:      []string{ScopeFull},
+			permissions: owner,
+			expected:    owner,
+		},
+		{
+			name:        "test_links_read_scope",
+			scopes:      []string{ScopeLinksRead},
+			permissions: owner,
+			expected:    []string{PermissionLinksRead},
+		},
+		{
+			name:        "test_scope_not_granted_to_owner",
+			scopes:      []string{ScopeLinksRead},
+			permissions: []string{PermissionUsersRead},
+			expected:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScopePermissions(tt.scopes, tt.permissions)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ScopePermissions() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewAPIKey(t *testing.T) {
+	key, prefix, hash, err := NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+
+	if !IsAPIKey(key) {
+		t.Errorf("IsAPIKey(%q) = false", key)
+	}
+	if key[:len(prefix)] != prefix {
+		t.Errorf("prefix %q does not start key %q", prefix, key)
+	}
+	if HashAPIKey(key) != hash {
+		t.Errorf("HashAPIKey() does not match the returned hash")
+	}
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Permissions granted to roles in the roles table. The *:manage permissions
+// allow access to resources of other users.
+const (
+	PermissionUsersRead   = "users:read"
+	PermissionUsersWrite  = "users:write"
+	PermissionUsersManage = "users:manage"
+	PermissionLinksRead   = "links:read"
+	PermissionLinksWrite  = "links:write"
+	PermissionLinksManage = "links:manage"
+)
+
+// Metadata keys under which the api-gw passes the caller to the backend services.
+const (
+	MetadataUserID          = "x-user-id"
+	MetadataUserRoles       = "x-user-roles"
+	MetadataUserPermissions = "x-user-permissions"
+	MetadataAPIKey          = "x-api-key-auth"
+)
+
+// Identity is the authenticated caller of a request.
+type Identity struct {
+	UserID      string
+	Roles       []string
+	Permissions []string
+	// APIKey is set when the caller authenticated with an API key rather
+	// than an access token.
+	APIKey bool
+}
+
+func (i Identity) HasRole(role string) bool {
+	return slices.Contains(i.Roles, role)
+}
+
+func (i Identity) Can(permission string) bool {
+	return slices.Contains(i.Permissions, permission)
+}
+
+// CanAccess reports whether the caller may access a resource owned by userID:
+// either the caller is the owner or it is granted the manage permission.
+func (i Identity) CanAccess(userID, manage string) bool {
+	return i.UserID == userID || i.Can(manage)
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// NewOutgoingContext attaches the identity to the metadata of outgoing gRPC calls.
+func NewOutgoingContext(ctx context.Context, id Identity) context.Context {
+	return metadata.AppendToOutgoingContext(
+		ctx,
+		MetadataUserID, id.UserID,
+		MetadataUserRoles, strings.Join(id.Roles, ","),
+		MetadataUserPermissions, strings.Join(id.Permissions, ","),
+		MetadataAPIKey, strconv.FormatBool(id.APIKey),
+	)
+}
+
+// IdentityFromIncomingContext reads the identity passed by the api-gw.
+func IdentityFromIncomingContext(ctx context.Context) (Identity, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Identity{}, false
+	}
+
+	userIDs := md.Get(MetadataUserID)
+	if len(userIDs) == 0 || userIDs[0] == "" {
+		return Identity{}, false
+	}
+
+	return Identity{
+		UserID:      userIDs[0],
+		Roles:       splitValues(md.Get(MetadataUserRoles)),
+		Permissions: splitValues(md.Get(MetadataUserPermissions)),
+		APIKey:      slices.Contains(md.Get(MetadataAPIKey), "true"),
+	}, true
+}
+
+func splitValues(values []string) []string {
+	var res []string
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			if s != "" {
+				res = append(res, s)
+			}
+		}
+	}
+
+	return res
+}
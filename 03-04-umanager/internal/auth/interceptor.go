@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor stores the caller passed by the api-gw in the context
+// of every call and checks the permission required by the called method.
+// permissions maps full method names to permissions; methods missing from the
+// map are public.
+func UnaryServerInterceptor(permissions map[string]string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		id, ok := IdentityFromIncomingContext(ctx)
+		if ok {
+			ctx = WithIdentity(ctx, id)
+		}
+
+		permission, protected := permissions[info.FullMethod]
+		if !protected {
+			return handler(ctx, req)
+		}
+
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+		}
+
+		if !id.Can(permission) {
+			return nil, status.Errorf(codes.PermissionDenied, "permission %s is required", permission)
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor(map[string]string{
+		"/pb.Service/Read":   PermissionLinksRead,
+		"/pb.Service/Manage": PermissionLinksManage,
+	})
+
+	user := Identity{UserID: "user-id", Roles: []string{"user"}, Permissions: []string{PermissionLinksRead}}
+	apiKey := user
+	apiKey.APIKey = true
+
+	tests := []struct {
+		name     string
+		method   string
+		identity *Identity
+		wantCode codes.Code
+	}{
+		{
+			name:     "test_public_method",
+			method:   "/pb.Service/Public",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "test_unauthenticated",
+			method:   "/pb.Service/Read",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "test_permitted",
+			method:   "/pb.Service/Read",
+			identity: &user,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "test_permitted_with_api_key",
+			method:   "/pb.Service/Read",
+			identity: &apiKey,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "test_permission_denied",
+			method:   "/pb.Service/Manage",
+			identity: &user,
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.identity != nil {
+				out := NewOutgoingContext(ctx, *tt.identity)
+				md, _ := metadata.FromOutgoingContext(out)
+				ctx = metadata.NewIncomingContext(ctx, md)
+			}
+
+			var got Identity
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				got, _ = IdentityFromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("UnaryServerInterceptor() code = %v, want %v", code, tt.wantCode)
+			}
+
+			if tt.wantCode == codes.OK && tt.identity != nil &&
+				(got.UserID != tt.identity.UserID || got.APIKey != tt.identity.APIKey) {
+				t.Errorf("IdentityFromContext() = %v, want %v", got, *tt.identity)
+			}
+		})
+	}
+}
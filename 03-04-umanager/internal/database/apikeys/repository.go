@@ -0,0 +1,129 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+)
+
+const columns = `id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_at`
+
+func New(db *pgxpool.Pool, timeout time.Duration) *Repository {
+	return &Repository{db: db, timeout: timeout}
+}
+
+type Repository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+func (r *Repository) Create(ctx context.Context, req database.CreateAPIKeyReq) (database.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	k := database.APIKey{
+		ID:        req.ID,
+		UserID:    req.UserID,
+		Name:      req.Name,
+		Prefix:    req.Prefix,
+		KeyHash:   req.KeyHash,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	query := `
+		INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	if _, err := r.db.Exec(
+		ctx, query, k.ID, k.UserID, k.Name, k.Prefix, k.KeyHash, k.Scopes, k.ExpiresAt, k.CreatedAt,
+	); err != nil {
+		return k, fmt.Errorf("postgres Exec: %w", err)
+	}
+
+	return k, nil
+}
+
+func (r *Repository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]database.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var keys []database.APIKey
+
+	rows, err := r.db.Query(ctx, `SELECT `+columns+` FROM api_keys WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		k, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		keys = append(keys, k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Use finds the key by its hash and records the time it was used at.
+// ErrNotFound is returned for an expired key as well as for a revoked one,
+// which is deleted, and their last use is left as is.
+func (r *Repository) Use(ctx context.Context, keyHash string) (database.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		UPDATE api_keys SET last_used_at = $2
+		WHERE key_hash = $1 AND (expires_at IS NULL OR expires_at > $2)
+		RETURNING ` + columns
+	k, err := scan(r.db.QueryRow(ctx, query, keyHash, time.Now()))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return k, database.ErrNotFound
+		}
+		return k, fmt.Errorf("postgres QueryRow Decode: %w", err)
+	}
+
+	return k, nil
+}
+
+// Delete removes the key of the user. ErrNotFound is returned when the user
+// has no key with the given ID.
+func (r *Repository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM api_keys WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("postgres Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+func scan(row pgx.Row) (database.APIKey, error) {
+	var k database.APIKey
+	err := row.Scan(
+		&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.KeyHash,
+		&k.Scopes, &k.ExpiresAt, &k.LastUsedAt, &k.CreatedAt,
+	)
+	return k, err
+}
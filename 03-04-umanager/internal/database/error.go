@@ -0,0 +1,10 @@
+package database
+
+import (
+	"errors"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+)
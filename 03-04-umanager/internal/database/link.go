@@ -15,6 +15,56 @@ type Link struct {
 	UserID    string             `bson:"user_id"`
 	CreatedAt time.Time          `bson:"created_at"`
 	UpdatedAt time.Time          `bson:"updated_at"`
+	// Enrichment is zero for the links stored before it was tracked.
+	Enrichment Enrichment `bson:"enrichment"`
+}
+
+// EnrichmentState is how far the scraping of the page of a link has got.
+type EnrichmentState string
+
+const (
+	// EnrichmentPending links are waiting to be scraped, for the first time
+	// or again.
+	EnrichmentPending EnrichmentState = "pending"
+	EnrichmentOK      EnrichmentState = "ok"
+	// EnrichmentFailed links could not be scraped in any of the attempts.
+	EnrichmentFailed EnrichmentState = "failed"
+	// EnrichmentSkipped links are not scraped as their pages cannot be: they
+	// are forbidden or are not HTML.
+	EnrichmentSkipped EnrichmentState = "skipped"
+	// EnrichmentBlocked links are not scraped as robots.txt of their sites
+	// disallows it.
+	EnrichmentBlocked EnrichmentState = "blocked"
+)
+
+// Enrichment is the state of the scraping of the page of a link. The page is
+// checked again periodically, so that the broken links are found.
+type Enrichment struct {
+	State EnrichmentState `bson:"state"`
+	// Attempts is the number of attempts since the link was last queued.
+	Attempts      int        `bson:"attempts"`
+	LastAttemptAt *time.Time `bson:"last_attempt_at,omitempty"`
+	// QueuedAt is when the link was last queued for scraping.
+	QueuedAt *time.Time `bson:"queued_at,omitempty"`
+	// HTTPStatus is the status of the last response, zero if there was none.
+	HTTPStatus int `bson:"http_status,omitempty"`
+	// RedirectURL is where the URL of the link was last redirected to.
+	RedirectURL string `bson:"redirect_url,omitempty"`
+	LastError   string `bson:"last_error,omitempty"`
+	// ConsecutiveFailures is the number of the checks failed in a row. The
+	// link is Broken once there are enough of them.
+	ConsecutiveFailures int  `bson:"consecutive_failures"`
+	Broken              bool `bson:"broken"`
+}
+
+// Requeued returns e of a link queued at t to be scraped again. The results
+// of the previous checks are kept until the next one.
+func (e Enrichment) Requeued(t time.Time) Enrichment {
+	e.State = EnrichmentPending
+	e.Attempts = 0
+	e.QueuedAt = &t
+
+	return e
 }
 
 type CreateLinkReq struct {
@@ -24,6 +74,8 @@ type CreateLinkReq struct {
 	Tags   []string
 	Images []string
 	UserID string
+	// Events are stored in the outbox along with the link.
+	Events []OutboxEvent
 }
 
 type UpdateLinkReq struct {
@@ -33,11 +85,30 @@ type UpdateLinkReq struct {
 	Tags   []string
 	Images []string
 	UserID string
+	// Enrichment replaces the one of the link unless it is nil.
+	Enrichment *Enrichment
+	// UpdatedAt, unless nil, applies the update only to the link last
+	// updated at that time. ErrConflict is returned if it was changed since.
+	UpdatedAt *time.Time
+	// Events are stored in the outbox along with the link.
+	Events []OutboxEvent
 }
 
 type FindLinkCriteria struct {
 	UserID *string
 	Tags   []string
-	Limit  *int64
-	Offset *int64
+	// TagsMatchAll requires links to have every tag instead of any of them.
+	TagsMatchAll bool
+	// Text is a full text search over title and URL.
+	Text          *string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	// Broken selects the broken links, or the working ones if false.
+	Broken   *bool
+	Limit    *int64
+	Offset   *int64
+	After    *Cursor
+	SortDesc bool
 }
@@ -2,6 +2,7 @@ package links
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,10 +12,13 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database/outbox"
 )
 
 const collection = "links"
 
+const textIndexName = "links_text"
+
 func New(db *mongo.Database, timeout time.Duration) *Repository {
 	return &Repository{db: db, timeout: timeout}
 }
@@ -24,6 +28,36 @@ type Repository struct {
 	timeout time.Duration
 }
 
+// EnsureIndexes creates the indexes used by FindByCriteria and
+// FindQueuedBefore. Creating an existing index is a no-op, so it is safe to
+// call on every start.
+func (r *Repository) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Collection(collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "title", Value: "text"}, {Key: "url", Value: "text"}},
+			// no stemming: titles come in different languages and URLs are not words
+			Options: options.Index().SetName(textIndexName).SetDefaultLanguage("none"),
+		},
+		{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "tags", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "enrichment.queued_at", Value: 1}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("mongo CreateMany: %w", err)
+	}
+
+	return nil
+}
+
 func (r *Repository) Create(ctx context.Context, req database.CreateLinkReq) (database.Link, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
@@ -39,50 +73,173 @@ func (r *Repository) Create(ctx context.Context, req database.CreateLinkReq) (da
 		UserID:    req.UserID,
 		CreatedAt: now,
 		UpdatedAt: now,
+		Enrichment: database.Enrichment{
+			State:    database.EnrichmentPending,
+			QueuedAt: &now,
+		},
 	}
-	if _, err := r.db.Collection(collection).InsertOne(ctx, l); err != nil {
-		return l, fmt.Errorf("mongo InsertOne: %w", err)
+	err := r.withEvents(ctx, req.Events, func(ctx mongo.SessionContext) error {
+		if _, err := r.db.Collection(collection).InsertOne(ctx, l); err != nil {
+			return fmt.Errorf("mongo InsertOne: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return l, database.ErrConflict
+		}
+		return l, err
 	}
 
 	return l, nil
 }
 
+// Update sets the fields of the link and returns it. The enrichment of the
+// link is kept unless req.Enrichment is set.
 func (r *Repository) Update(ctx context.Context, req database.UpdateLinkReq) (database.Link, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	now := time.Now()
+	set := bson.M{
+		"title":      req.Title,
+		"url":        req.URL,
+		"images":     req.Images,
+		"tags":       req.Tags,
+		"user_id":    req.UserID,
+		"updated_at": time.Now(),
+	}
+	if req.Enrichment != nil {
+		set["enrichment"] = *req.Enrichment
+	}
 
-	l := database.Link{
-		ID:        req.ID,
-		Title:     req.Title,
-		URL:       req.URL,
-		Images:    req.Images,
-		Tags:      req.Tags,
-		UserID:    req.UserID,
-		CreatedAt: now, // обновляем и created_at также. Подумайте как поменять методы так,
-		// чтобы поле created_at не обновлялось, это можно сделать разными способоами
-		UpdatedAt: now,
+	filter := bson.M{"_id": req.ID}
+	if req.UpdatedAt != nil {
+		filter["updated_at"] = *req.UpdatedAt
 	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	opts := options.Replace().SetUpsert(true)
+	var l database.Link
+	err := r.withEvents(ctx, req.Events, func(ctx mongo.SessionContext) error {
+		err := r.db.Collection(collection).FindOneAndUpdate(ctx, filter, bson.M{"$set": set}, opts).Decode(&l)
+		if errors.Is(err, mongo.ErrNoDocuments) && req.UpdatedAt != nil {
+			// the link is either changed or deleted, the caller finds out
+			// which when it reads it again
+			return database.ErrConflict
+		}
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return database.ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("mongo FindOneAndUpdate: %w", err)
+		}
+		return nil
+	})
 
-	if _, err := r.db.Collection(collection).ReplaceOne(ctx, bson.M{"_id": req.ID}, l, opts); err != nil {
-		return l, fmt.Errorf("mongo ReplaceOne: %w", err)
-	}
+	return l, err
+}
 
-	return l, nil
+// SetEnrichment replaces the enrichment of the link and stores events in the
+// outbox in one transaction.
+func (r *Repository) SetEnrichment(
+	ctx context.Context, id primitive.ObjectID, e database.Enrichment, events ...database.OutboxEvent,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	return r.withEvents(ctx, events, func(ctx mongo.SessionContext) error {
+		res, err := r.db.Collection(collection).UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"enrichment": e}})
+		if err != nil {
+			return fmt.Errorf("mongo UpdateOne: %w", err)
+		}
+		if res.MatchedCount == 0 {
+			return database.ErrNotFound
+		}
+		return nil
+	})
 }
 
-func (r *Repository) Delete(ctx context.Context, id primitive.ObjectID) error {
+// Requeue queues the link for scraping again at t and stores events in the
+// outbox in one transaction. The link is updated only while its enrichment
+// still has the state and the queue time of prev, otherwise it was queued or
+// checked meanwhile and ErrNotFound is returned, so that a link is not queued
+// twice by the instances of a service running at once.
+func (r *Repository) Requeue(
+	ctx context.Context, id primitive.ObjectID, prev database.Enrichment, t time.Time, events ...database.OutboxEvent,
+) error {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	if _, err := r.db.Collection(collection).DeleteOne(ctx, bson.M{"_id": id}); err != nil {
-		return fmt.Errorf("mongo DeletOne: %w", err)
+	// the links stored before the checks have no enrichment, and a missing
+	// field matches null
+	filter := bson.M{"_id": id, "enrichment.queued_at": nil, "enrichment.state": nil}
+	if prev.QueuedAt != nil {
+		filter["enrichment.queued_at"] = *prev.QueuedAt
 	}
+	if prev.State != "" {
+		filter["enrichment.state"] = prev.State
+	}
+	update := bson.M{"$set": bson.M{
+		"enrichment.state":     database.EnrichmentPending,
+		"enrichment.attempts":  0,
+		"enrichment.queued_at": t,
+	}}
+
+	return r.withEvents(ctx, events, func(ctx mongo.SessionContext) error {
+		res, err := r.db.Collection(collection).UpdateOne(ctx, filter, update)
+		if err != nil {
+			return fmt.Errorf("mongo UpdateOne: %w", err)
+		}
+		if res.MatchedCount == 0 {
+			return database.ErrNotFound
+		}
+		return nil
+	})
+}
 
-	return nil
+// Delete deletes the link and stores events in the outbox in one transaction.
+func (r *Repository) Delete(ctx context.Context, id primitive.ObjectID, events ...database.OutboxEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	return r.withEvents(ctx, events, func(ctx mongo.SessionContext) error {
+		res, err := r.db.Collection(collection).DeleteOne(ctx, bson.M{"_id": id})
+		if err != nil {
+			return fmt.Errorf("mongo DeletOne: %w", err)
+		}
+		if res.DeletedCount == 0 {
+			return database.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// withEvents runs write in a transaction that also stores events in the
+// outbox, so that the change and its events are written together or not at
+// all.
+func (r *Repository) withEvents(
+	ctx context.Context, events []database.OutboxEvent, write func(ctx mongo.SessionContext) error,
+) error {
+	return r.db.Client().UseSession(ctx, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (interface{}, error) {
+			if err := write(sc); err != nil {
+				return nil, err
+			}
+			if len(events) == 0 {
+				return nil, nil
+			}
+
+			docs := make([]interface{}, len(events))
+			for i, e := range events {
+				docs[i] = e
+			}
+			if _, err := r.db.Collection(outbox.Collection).InsertMany(sc, docs); err != nil {
+				return nil, fmt.Errorf("mongo InsertMany: %w", err)
+			}
+
+			return nil, nil
+		})
+		return err
+	})
 }
 
 func (r *Repository) FindByID(ctx context.Context, id primitive.ObjectID) (database.Link, error) {
@@ -91,6 +248,9 @@ func (r *Repository) FindByID(ctx context.Context, id primitive.ObjectID) (datab
 	var l database.Link
 	result := r.db.Collection(collection).FindOne(ctx, bson.M{"_id": id})
 	if err := result.Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return l, database.ErrNotFound
+		}
 		return l, fmt.Errorf("mongo FindOne: %w", err)
 	}
 
@@ -127,6 +287,9 @@ func (r *Repository) FindByUserAndURL(ctx context.Context, link, userID string)
 	defer cancel()
 	result := r.db.Collection(collection).FindOne(ctx, bson.M{"url": link, "user_id": userID})
 	if err := result.Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return l, database.ErrNotFound
+		}
 		return l, fmt.Errorf("mongo FindOne: %w", err)
 	}
 
@@ -164,7 +327,47 @@ func (r *Repository) FindByCriteria(ctx context.Context, criteria database.FindL
 			tagsCriteria = append(tagsCriteria, tag)
 		}
 
-		filter["tags"] = bson.M{"$in": tagsCriteria}
+		op := "$in"
+		if criteria.TagsMatchAll {
+			op = "$all"
+		}
+		filter["tags"] = bson.M{op: tagsCriteria}
+	}
+	if criteria.Text != nil && *criteria.Text != "" {
+		filter["$text"] = bson.M{"$search": *criteria.Text}
+	}
+	if created := dateRange(criteria.CreatedAfter, criteria.CreatedBefore); created != nil {
+		filter["created_at"] = created
+	}
+	if updated := dateRange(criteria.UpdatedAfter, criteria.UpdatedBefore); updated != nil {
+		filter["updated_at"] = updated
+	}
+
+	if criteria.Broken != nil {
+		if *criteria.Broken {
+			filter["enrichment.broken"] = true
+		} else {
+			// the links stored before the checks have no enrichment
+			filter["enrichment.broken"] = bson.M{"$ne": true}
+		}
+	}
+
+	order, op := 1, "$gt"
+	if criteria.SortDesc {
+		order, op = -1, "$lt"
+	}
+	opts.SetSort(bson.D{{Key: "created_at", Value: order}, {Key: "_id", Value: order}})
+
+	if criteria.After != nil {
+		id, err := primitive.ObjectIDFromHex(criteria.After.ID)
+		if err != nil {
+			return nil, database.ErrInvalidCursor
+		}
+
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{op: criteria.After.CreatedAt}},
+			bson.M{"created_at": criteria.After.CreatedAt, "_id": bson.M{op: id}},
+		}
 	}
 
 	cursor, err := r.db.Collection(collection).Find(ctx, filter, opts)
@@ -182,3 +385,48 @@ func (r *Repository) FindByCriteria(ctx context.Context, criteria database.FindL
 
 	return links, nil
 }
+
+// FindQueuedBefore returns up to limit links last queued for scraping before
+// the time, the least recently queued first.
+func (r *Repository) FindQueuedBefore(ctx context.Context, before time.Time, limit int64) ([]database.Link, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// the links stored before the checks were never queued
+	filter := bson.M{"$or": bson.A{
+		bson.M{"enrichment.queued_at": bson.M{"$lt": before}},
+		bson.M{"enrichment.queued_at": bson.M{"$exists": false}},
+	}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "enrichment.queued_at", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.db.Collection(collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("mongo Find: %w", err)
+	}
+
+	var links []database.Link
+	if err := cursor.All(ctx, &links); err != nil {
+		return nil, fmt.Errorf("mongo All: %w", err)
+	}
+
+	return links, nil
+}
+
+// dateRange builds a filter for the half-open range [after, before).
+func dateRange(after, before *time.Time) bson.M {
+	if after == nil && before == nil {
+		return nil
+	}
+
+	m := bson.M{}
+	if after != nil {
+		m["$gte"] = *after
+	}
+	if before != nil {
+		m["$lt"] = *before
+	}
+
+	return m
+}
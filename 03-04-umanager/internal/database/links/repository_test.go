@@ -50,6 +50,7 @@ func TestMain(m *testing.M) {
 					},
 					MaxPoolSize: &cfg.LinksService.Mongo.MaxPoolSize,
 					MinPoolSize: &cfg.LinksService.Mongo.MinPoolSize,
+					Direct:      &cfg.LinksService.Mongo.Direct,
 				},
 			)
 			if err != nil {
@@ -103,7 +104,7 @@ func TestRepository_Update(t *testing.T) {
 
 	id := primitive.NewObjectID()
 	userID := uuid.New().String()
-	_, err := linksRepo.Create(
+	created, err := linksRepo.Create(
 		ctx, database.CreateLinkReq{
 			ID:     id,
 			URL:    "https://ya.ru",
@@ -135,6 +136,21 @@ func TestRepository_Update(t *testing.T) {
 	if updated.Title != expectedTitle {
 		assert.Equal(t, updated.Title, expectedTitle)
 	}
+
+	// the creation time is kept for the cursors and the date filters
+	require.True(t, updated.CreatedAt.Equal(created.CreatedAt.Truncate(time.Millisecond)))
+
+	_, err = linksRepo.Update(ctx, database.UpdateLinkReq{ID: primitive.NewObjectID(), URL: expectedURL})
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// the link has been updated since it was created
+	_, err = linksRepo.Update(ctx, database.UpdateLinkReq{ID: id, URL: expectedURL, UpdatedAt: &created.UpdatedAt})
+	require.ErrorIs(t, err, database.ErrConflict)
+
+	_, err = linksRepo.Update(
+		ctx, database.UpdateLinkReq{ID: id, URL: expectedURL, Title: "scraped", UpdatedAt: &updated.UpdatedAt},
+	)
+	require.NoError(t, err)
 }
 
 func TestRepository_FindByUserID(t *testing.T) {
@@ -208,9 +224,49 @@ func TestRepository_Delete(t *testing.T) {
 	require.NoError(t, err)
 
 	_, err = linksRepo.FindByID(ctx, id)
-	if err != nil {
-		if !errors.Is(err, mongo.ErrNoDocuments) {
-			t.Fatal(err)
-		}
+	if !errors.Is(err, database.ErrNotFound) {
+		t.Fatal(err)
+	}
+}
+
+func TestRepository_Requeue(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip()
 	}
+
+	ctx := context.Background()
+
+	id := primitive.NewObjectID()
+	_, err := linksRepo.Create(
+		ctx, database.CreateLinkReq{
+			ID:     id,
+			URL:    "https://ya.ru",
+			Title:  "ya",
+			UserID: uuid.New().String(),
+		},
+	)
+	require.NoError(t, err)
+
+	failed := database.Enrichment{State: database.EnrichmentFailed, ConsecutiveFailures: 3, Broken: true}
+	require.NoError(t, linksRepo.SetEnrichment(ctx, id, failed))
+
+	found, err := linksRepo.FindByID(ctx, id)
+	require.NoError(t, err)
+
+	err = linksRepo.Requeue(ctx, id, found.Enrichment, time.Now())
+	require.NoError(t, err)
+
+	queued, err := linksRepo.FindByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, database.EnrichmentPending, queued.Enrichment.State)
+	require.NotNil(t, queued.Enrichment.QueuedAt)
+	// the results of the previous checks are kept until the next one
+	require.Equal(t, 3, queued.Enrichment.ConsecutiveFailures)
+	require.True(t, queued.Enrichment.Broken)
+
+	// the link is queued already, so the stale enrichment does not match
+	err = linksRepo.Requeue(ctx, id, found.Enrichment, time.Now())
+	require.ErrorIs(t, err, database.ErrNotFound)
 }
@@ -0,0 +1,22 @@
+package database
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxEvent is an event to publish to the event bus. It is stored in the same
+// transaction as the change it is about and published later by a relay, so
+// that the change and the event are never separated.
+type OutboxEvent struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	RoutingKey  string             `bson:"routing_key"`
+	ContentType string             `bson:"content_type"`
+	Body        []byte             `bson:"body"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	// SentAt is nil until the bus accepts the event.
+	SentAt    *time.Time `bson:"sent_at"`
+	Attempts  int        `bson:"attempts"`
+	LastError string     `bson:"last_error,omitempty"`
+}
@@ -0,0 +1,107 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+)
+
+// Collection is written by the repositories storing events along with their
+// changes.
+const Collection = "outbox"
+
+func New(db *mongo.Database, timeout time.Duration) *Repository {
+	return &Repository{db: db, timeout: timeout}
+}
+
+type Repository struct {
+	db      *mongo.Database
+	timeout time.Duration
+}
+
+// EnsureIndexes creates the index used by FindPending and removes sent events
+// after retention.
+func (r *Repository) EnsureIndexes(ctx context.Context, retention time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Collection(Collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "sent_at", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			// pending events have no sent_at, so they never expire
+			Keys:    bson.D{{Key: "sent_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(retention.Seconds())),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("mongo CreateMany: %w", err)
+	}
+
+	return nil
+}
+
+// FindPending returns up to limit unsent events created after the event
+// after, in the order of creation. A zero after starts from the oldest event.
+func (r *Repository) FindPending(
+	ctx context.Context, after primitive.ObjectID, limit int64,
+) ([]database.OutboxEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"sent_at": nil}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+	cursor, err := r.db.Collection(Collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("mongo Find: %w", err)
+	}
+
+	var res []database.OutboxEvent
+	if err := cursor.All(ctx, &res); err != nil {
+		return nil, fmt.Errorf("mongo All: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *Repository) MarkSent(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Collection(Collection).UpdateByID(ctx, id, bson.M{
+		"$set": bson.M{"sent_at": time.Now()},
+		"$inc": bson.M{"attempts": 1},
+	})
+	if err != nil {
+		return fmt.Errorf("mongo UpdateByID: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkFailed(ctx context.Context, id primitive.ObjectID, cause error) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Collection(Collection).UpdateByID(ctx, id, bson.M{
+		"$set": bson.M{"last_error": cause.Error()},
+		"$inc": bson.M{"attempts": 1},
+	})
+	if err != nil {
+		return fmt.Errorf("mongo UpdateByID: %w", err)
+	}
+
+	return nil
+}
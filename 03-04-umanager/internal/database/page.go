@@ -0,0 +1,91 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+const (
+	SortCreatedAtAsc  = "created_at"
+	SortCreatedAtDesc = "-created_at"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidSort   = errors.New("invalid sort")
+)
+
+// Cursor points at the last item of a page of a list ordered by creation time.
+// The ID orders items created at the same moment.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+// EncodeCursor returns the cursor as an opaque page token.
+func EncodeCursor(c Cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a page token; an empty token yields a nil cursor.
+func DecodeCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// Page is a page of a list ordered by creation time.
+type Page struct {
+	Limit    int64
+	After    *Cursor
+	SortDesc bool
+}
+
+// ParsePage builds a page from the page size, page token and sort of a list request.
+func ParsePage(size int32, token, sort string) (Page, error) {
+	var (
+		p   Page
+		err error
+	)
+
+	switch {
+	case size <= 0:
+		p.Limit = DefaultPageSize
+	case size > MaxPageSize:
+		p.Limit = MaxPageSize
+	default:
+		p.Limit = int64(size)
+	}
+
+	switch sort {
+	case "", SortCreatedAtAsc:
+	case SortCreatedAtDesc:
+		p.SortDesc = true
+	default:
+		return p, ErrInvalidSort
+	}
+
+	p.After, err = DecodeCursor(token)
+
+	return p, err
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParsePage(t *testing.T) {
+	cursor := Cursor{CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ID: "42"}
+
+	tests := []struct {
+		name     string
+		size     int32
+		token    string
+		sort     string
+		expected Page
+		err      error
+	}{
+		{
+			name:     "test_defaults",
+			expected: Page{Limit: DefaultPageSize},
+		},
+		{
+			name:     "test_max_page_size",
+			size:     MaxPageSize + 1,
+			expected: Page{Limit: MaxPageSize},
+		},
+		{
+			name:     "test_desc_with_cursor",
+			size:     10,
+			token:    EncodeCursor(cursor),
+			sort:     SortCreatedAtDesc,
+			expected: Page{Limit: 10, After: &cursor, SortDesc: true},
+		},
+		{
+			name: "test_invalid_sort",
+			sort: "username",
+			err:  ErrInvalidSort,
+		},
+		{
+			name:  "test_invalid_cursor",
+			token: "not a cursor",
+			err:   ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePage(tt.size, tt.token, tt.sort)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParsePage() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if got.Limit != tt.expected.Limit || got.SortDesc != tt.expected.SortDesc {
+				t.Errorf("ParsePage() = %+v, want %+v", got, tt.expected)
+			}
+			if (got.After == nil) != (tt.expected.After == nil) ||
+				got.After != nil && (got.After.ID != tt.expected.After.ID || !got.After.CreatedAt.Equal(tt.expected.After.CreatedAt)) {
+				t.Errorf("ParsePage() cursor = %+v, want %+v", got.After, tt.expected.After)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+)
+
+const insertQuery = `
+	INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+func New(db *pgxpool.Pool, timeout time.Duration) *Repository {
+	return &Repository{db: db, timeout: timeout}
+}
+
+type Repository struct {
+	db      *pgxpool.Pool
+	timeout time.Duration
+}
+
+func (r *Repository) Create(ctx context.Context, req database.CreateRefreshTokenReq) (database.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	t := database.RefreshToken{
+		ID:        req.ID,
+		UserID:    req.UserID,
+		FamilyID:  req.FamilyID,
+		TokenHash: req.TokenHash,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	if _, err := r.db.Exec(
+		ctx, insertQuery, t.ID, t.UserID, t.FamilyID, t.TokenHash, t.ExpiresAt, t.CreatedAt,
+	); err != nil {
+		return t, fmt.Errorf("postgres Exec: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *Repository) FindByHash(ctx context.Context, tokenHash string) (database.RefreshToken, error) {
+	var t database.RefreshToken
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		SELECT id, user_id, family_id, token_hash, expires_at, revoked_at, replaced_by, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+	if err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash,
+		&t.ExpiresAt, &t.RevokedAt, &t.ReplacedBy, &t.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return t, database.ErrNotFound
+		}
+		return t, fmt.Errorf("postgres QueryRow Decode: %w", err)
+	}
+
+	return t, nil
+}
+
+// Rotate revokes the token with oldID and stores its replacement in a single
+// transaction. ErrConflict is returned when the old token has already been
+// revoked, e.g. by a concurrent refresh.
+func (r *Repository) Rotate(
+	ctx context.Context, oldID uuid.UUID, req database.CreateRefreshTokenReq,
+) (database.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	t := database.RefreshToken{
+		ID:        req.ID,
+		UserID:    req.UserID,
+		FamilyID:  req.FamilyID,
+		TokenHash: req.TokenHash,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: now,
+	}
+
+	err := r.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		query := `UPDATE refresh_tokens SET revoked_at = $2, replaced_by = $3 WHERE id = $1 AND revoked_at IS NULL`
+		tag, err := tx.Exec(ctx, query, oldID, now, t.ID)
+		if err != nil {
+			return fmt.Errorf("postgres Exec: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return database.ErrConflict
+		}
+
+		if _, err := tx.Exec(
+			ctx, insertQuery, t.ID, t.UserID, t.FamilyID, t.TokenHash, t.ExpiresAt, t.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("postgres Exec: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return t, err
+	}
+
+	return t, nil
+}
+
+func (r *Repository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE refresh_tokens SET revoked_at = $2 WHERE family_id = $1 AND revoked_at IS NULL`
+	if _, err := r.db.Exec(ctx, query, familyID, time.Now()); err != nil {
+		return fmt.Errorf("postgres Exec: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RevokeByUserID(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE refresh_tokens SET revoked_at = $2 WHERE user_id = $1 AND revoked_at IS NULL`
+	if _, err := r.db.Exec(ctx, query, userID, time.Now()); err != nil {
+		return fmt.Errorf("postgres Exec: %w", err)
+	}
+
+	return nil
+}
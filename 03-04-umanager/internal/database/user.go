@@ -6,10 +6,17 @@ import (
 	"github.com/google/uuid"
 )
 
+// Roles created by the migrations. Their permissions are stored in the roles table.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
 	ID        uuid.UUID `db:"id"`
 	Username  string    `db:"username"`
 	Password  string    `db:"password"`
+	Roles     []string  `db:"roles"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 }
@@ -24,9 +31,18 @@ type UpdateUserReq struct {
 	ID       uuid.UUID
 	Username string
 	Password string
+	Roles    []string
+}
+
+type Role struct {
+	Name        string   `db:"name"`
+	Permissions []string `db:"permissions"`
 }
 
 type FindUserCriteria struct {
 	ID       *uuid.UUID
 	Username *string
+	Limit    *int64
+	After    *Cursor
+	SortDesc bool
 }
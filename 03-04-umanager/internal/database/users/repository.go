@@ -2,15 +2,24 @@ package users
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
 )
 
+const selectQuery = `SELECT id, username, password, roles, created_at, updated_at FROM users`
+
+// uniqueViolation is the SQLSTATE of a unique constraint violation.
+const uniqueViolation = "23505"
+
 func New(userDB *pgxpool.Pool, timeout time.Duration) *Repository {
 	return &Repository{db: userDB, timeout: timeout}
 }
@@ -20,6 +29,8 @@ type Repository struct {
 	timeout time.Duration
 }
 
+// Create stores a new user. ErrConflict is returned when the ID or the
+// username is taken, an existing user is never overwritten.
 func (r *Repository) Create(ctx context.Context, req database.CreateUserReq) (database.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
@@ -29,6 +40,7 @@ func (r *Repository) Create(ctx context.Context, req database.CreateUserReq) (da
 		ID:        req.ID,
 		Username:  req.Username,
 		Password:  req.Password,
+		Roles:     []string{database.RoleUser},
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -36,24 +48,63 @@ func (r *Repository) Create(ctx context.Context, req database.CreateUserReq) (da
 	query := `
 		INSERT INTO users (id, username, password, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (id) DO UPDATE
-		SET username = $2, password = $3, updated_at = $5
 	`
 	if _, err := r.db.Exec(ctx, query, u.ID, u.Username, u.Password, now, now); err != nil {
-		return u, fmt.Errorf("postgres Exec: %w", err)
+		return u, queryError("postgres Exec", err)
 	}
 
 	return u, nil
 }
 
+func (r *Repository) Update(ctx context.Context, req database.UpdateUserReq) (database.User, error) {
+	var u database.User
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `
+		UPDATE users
+		SET username = COALESCE(NULLIF($2, ''), username),
+			password = COALESCE(NULLIF($3, ''), password),
+			roles = COALESCE(NULLIF($4, '{}'::TEXT[]), roles),
+			updated_at = $5
+		WHERE id = $1
+		RETURNING id, username, password, roles, created_at, updated_at
+	`
+	if err := r.db.QueryRow(ctx, query, req.ID, req.Username, req.Password, req.Roles, time.Now()).Scan(
+		&u.ID, &u.Username,
+		&u.Password, &u.Roles, &u.CreatedAt, &u.UpdatedAt,
+	); err != nil {
+		return u, queryError("postgres QueryRow Decode", err)
+	}
+
+	return u, nil
+}
+
+func (r *Repository) UpdatePassword(ctx context.Context, userID uuid.UUID, password string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	query := `UPDATE users SET password = $2, updated_at = $3 WHERE id = $1`
+	if _, err := r.db.Exec(ctx, query, userID, password, time.Now()); err != nil {
+		return fmt.Errorf("postgres Exec: %w", err)
+	}
+
+	return nil
+}
+
 func (r *Repository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	query := `DELETE FROM users WHERE id=$1`
-	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+	tag, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
 		return fmt.Errorf("postgres Exec: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
 	return nil
 }
 
@@ -63,25 +114,65 @@ func (r *Repository) FindByID(ctx context.Context, userID uuid.UUID) (database.U
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	if err := r.db.QueryRow(ctx, `SELECT * FROM users WHERE id=$1`, userID).Scan(
+	if err := r.db.QueryRow(ctx, selectQuery+` WHERE id=$1`, userID).Scan(
 		&u.ID, &u.Username,
-		&u.Password, &u.CreatedAt, &u.UpdatedAt,
+		&u.Password, &u.Roles, &u.CreatedAt, &u.UpdatedAt,
 	); err != nil {
-		return u, fmt.Errorf("postgres QueryRow Decode: %w", err)
+		return u, queryError("postgres QueryRow Decode", err)
 	}
 
 	return u, nil
 }
 
 func (r *Repository) FindAll(ctx context.Context) ([]database.User, error) {
+	return r.FindByCriteria(ctx, database.FindUserCriteria{})
+}
+
+func (r *Repository) FindByCriteria(ctx context.Context, criteria database.FindUserCriteria) ([]database.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	var users []database.User
+	var (
+		users []database.User
+		where []string
+		args  []interface{}
+	)
+
+	if criteria.ID != nil {
+		args = append(args, *criteria.ID)
+		where = append(where, fmt.Sprintf("id = $%d", len(args)))
+	}
+	if criteria.Username != nil {
+		args = append(args, *criteria.Username)
+		where = append(where, fmt.Sprintf("username = $%d", len(args)))
+	}
 
-	query := `SELECT id, username, password, created_at, updated_at FROM users`
+	order, op := "ASC", ">"
+	if criteria.SortDesc {
+		order, op = "DESC", "<"
+	}
 
-	rows, err := r.db.Query(ctx, query)
+	if criteria.After != nil {
+		id, err := uuid.Parse(criteria.After.ID)
+		if err != nil {
+			return nil, database.ErrInvalidCursor
+		}
+
+		args = append(args, criteria.After.CreatedAt, id)
+		where = append(where, fmt.Sprintf("(created_at, id) %s ($%d, $%d)", op, len(args)-1, len(args)))
+	}
+
+	query := selectQuery
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY created_at %s, id %s", order, order)
+	if criteria.Limit != nil {
+		args = append(args, *criteria.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("postgres Query: %w", err)
 	}
@@ -89,7 +180,7 @@ func (r *Repository) FindAll(ctx context.Context) ([]database.User, error) {
 
 	for rows.Next() {
 		var user database.User
-		err := rows.Scan(&user.ID, &user.Username, &user.Password, &user.CreatedAt, &user.UpdatedAt)
+		err := rows.Scan(&user.ID, &user.Username, &user.Password, &user.Roles, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -109,12 +200,54 @@ func (r *Repository) FindByUsername(ctx context.Context, username string) (datab
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	if err := r.db.QueryRow(ctx, `SELECT * FROM users WHERE username=$1`, username).Scan(
+	if err := r.db.QueryRow(ctx, selectQuery+` WHERE username=$1`, username).Scan(
 		&u.ID, &u.Username,
-		&u.Password, &u.CreatedAt, &u.UpdatedAt,
+		&u.Password, &u.Roles, &u.CreatedAt, &u.UpdatedAt,
 	); err != nil {
-		return u, fmt.Errorf("postgres QueryRow Decode: %w", err)
+		return u, queryError("postgres QueryRow Decode", err)
 	}
 
 	return u, nil
 }
+
+func (r *Repository) FindRoles(ctx context.Context, names []string) ([]database.Role, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var roles []database.Role
+
+	rows, err := r.db.Query(ctx, `SELECT name, permissions FROM roles WHERE name = ANY($1)`, names)
+	if err != nil {
+		return nil, fmt.Errorf("postgres Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var role database.Role
+		if err := rows.Scan(&role.Name, &role.Permissions); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return roles, nil
+}
+
+// queryError replaces errors with a meaning for the callers by the database
+// sentinel errors and wraps the rest with op.
+func queryError(op string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.ErrNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return fmt.Errorf("%w: %s", database.ErrConflict, pgErr.ConstraintName)
+	}
+
+	return fmt.Errorf("%s: %w", op, err)
+}
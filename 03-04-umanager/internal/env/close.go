@@ -3,36 +3,32 @@ package env
 import (
 	"context"
 	"log/slog"
-
-	"github.com/jackc/pgx/v4/pgxpool"
-	amqp "github.com/rabbitmq/amqp091-go"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// Closer releases the connections opened by a setup function in the reverse
+// order of opening.
 type Closer struct {
-	usersDBConn *pgxpool.Pool
-	linksDBConn *mongo.Client
-	amqpConn    *amqp.Connection
-	amqpChannel *amqp.Channel
+	closers []func(ctx context.Context)
+}
+
+func (c *Closer) add(name string, close func(ctx context.Context) error) {
+	c.closers = append(c.closers, func(ctx context.Context) {
+		if err := close(ctx); err != nil {
+			slog.Error("closing", slog.String("name", name), slog.Any("err", err))
+		}
+	})
 }
 
-func NewCloser(usersDBConn *pgxpool.Pool, linksDBConn *mongo.Client, amqpConn *amqp.Connection, amqpChannel *amqp.Channel) *Closer {
-	return &Closer{
-		usersDBConn: usersDBConn,
-		linksDBConn: linksDBConn,
-		amqpConn:    amqpConn,
-		amqpChannel: amqpChannel,
+// Merge appends the closers of others, so that they are closed before the
+// ones already in c.
+func (c *Closer) Merge(others ...*Closer) {
+	for _, o := range others {
+		c.closers = append(c.closers, o.closers...)
 	}
 }
 
 func (c *Closer) Close(ctx context.Context) {
-	defer c.amqpChannel.Close()
-	defer c.amqpConn.Close()
-	defer func() {
-		err := c.linksDBConn.Disconnect(ctx)
-		if err != nil {
-			slog.Error("closing", slog.Any("err", err))
-		}
-	}()
-	defer c.usersDBConn.Close()
+	for i := len(c.closers) - 1; i >= 0; i-- {
+		c.closers[i](ctx)
+	}
 }
@@ -13,29 +13,117 @@ type Config struct {
 	APIGWService APIGWService `env:",prefix=APIGW_"`
 }
 
+// Users is the part of Config used by the users service.
+type Users struct {
+	UsersService UsersService `env:",prefix=USERS_"`
+}
+
+// Links is the part of Config used by the links service.
+type Links struct {
+	LinksService LinksService `env:",prefix=LINKS_"`
+}
+
+// Gateway is the part of Config used by the api-gw. The gateway checks access
+// tokens itself, so it shares the auth settings of the users service.
+type Gateway struct {
+	APIGWService APIGWService `env:",prefix=APIGW_"`
+	Auth         AuthConfig   `env:",prefix=USERS_AUTH_"`
+}
+
 type AMQPConfig struct {
-	User      string `env:"USER,default=guest"`
-	Password  string `env:"PASSWORD,default=guest"`
-	Host      string `env:"HOST,default=localhost"`
-	Port      int16  `env:"PORT,default=5672"`
-	QueueName string `env:"QNAME,default=final-queue"`
+	User        string        `env:"USER,default=guest"`
+	Password    string        `env:"PASSWORD,default=guest"`
+	Host        string        `env:"HOST,default=localhost"`
+	Port        int16         `env:"PORT,default=5672"`
+	QueueName   string        `env:"QNAME,default=final-queue"`
+	Exchange    string        `env:"EXCHANGE,default=links"`
+	MaxAttempts int           `env:"MAX_ATTEMPTS,default=5"`
+	RetryDelay  time.Duration `env:"RETRY_DELAY,default=1s"`
+	// Workers is also the prefetch count of the consumer.
+	Workers         int           `env:"WORKERS,default=4"`
+	HostRate        float64       `env:"HOST_RATE,default=1"`
+	HostBurst       int           `env:"HOST_BURST,default=2"`
+	HostConcurrency int           `env:"HOST_CONCURRENCY,default=2"`
+	StatsInterval   time.Duration `env:"STATS_INTERVAL,default=30s"`
+	// The delay between the reconnection attempts grows from ReconnectMin
+	// to ReconnectMax.
+	ReconnectMin time.Duration `env:"RECONNECT_MIN,default=500ms"`
+	ReconnectMax time.Duration `env:"RECONNECT_MAX,default=30s"`
 }
 
 func (a AMQPConfig) String() string {
 	return fmt.Sprintf("amqp://%s:%s@%s:%d/", a.User, a.Password, a.Host, a.Port)
 }
 
+// Event bus implementations.
+const (
+	EventBusRabbitMQ = "rabbitmq"
+	EventBusMemory   = "memory"
+)
+
 type LinksService struct {
 	Mongo      MongoConfig     `env:",prefix=DB_"`
 	GRPCServer LinksGRPCConfig `env:",prefix=GRPC_"`
-	AMQP       AMQPConfig      `env:",prefix=AMQP_"`
+	// EventBus selects the event bus. The queue and the workers of the link
+	// updater are configured by AMQP with either bus.
+	EventBus string        `env:"EVENT_BUS,default=rabbitmq"`
+	AMQP     AMQPConfig    `env:",prefix=AMQP_"`
+	Outbox   OutboxConfig  `env:",prefix=OUTBOX_"`
+	Scrape   ScrapeConfig  `env:",prefix=SCRAPE_"`
+	Recheck  RecheckConfig `env:",prefix=RECHECK_"`
+	// ShutdownTimeout is how long in-flight calls and the message being
+	// processed by the link updater are waited for on shutdown.
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT,default=10s"`
 }
 
 type LinksGRPCConfig struct {
-	Addr    string        `env:"ADDR,default=:51000"`
-	Timeout time.Duration `env:"TIMEOUT,default=10s"`
+	Addr           string        `env:"ADDR,default=:51000"`
+	Timeout        time.Duration `env:"TIMEOUT,default=10s"`
+	HealthInterval time.Duration `env:"HEALTH_INTERVAL,default=5s"`
 }
 
+// ScrapeConfig limits the fetching of the pages of the links.
+type ScrapeConfig struct {
+	ConnectTimeout time.Duration `env:"CONNECT_TIMEOUT,default=5s"`
+	ReadTimeout    time.Duration `env:"READ_TIMEOUT,default=10s"`
+	MaxBodySize    int64         `env:"MAX_BODY_SIZE,default=2097152"`
+	MaxRedirects   int           `env:"MAX_REDIRECTS,default=5"`
+	ContentTypes   []string      `env:"CONTENT_TYPES,default=text/html,application/xhtml+xml"`
+	// AllowHosts and AllowNetworks are the internal hosts and CIDR networks
+	// that may be fetched.
+	AllowHosts    []string `env:"ALLOW_HOSTS"`
+	AllowNetworks []string `env:"ALLOW_NETWORKS"`
+	// UserAgent and ContactURL identify the crawler to the sites, which
+	// match the product token of UserAgent in robots.txt.
+	UserAgent     string        `env:"USER_AGENT,default=umanager/1.0"`
+	ContactURL    string        `env:"CONTACT_URL"`
+	RobotsTTL     time.Duration `env:"ROBOTS_TTL,default=24h"`
+	MaxCrawlDelay time.Duration `env:"MAX_CRAWL_DELAY,default=1m"`
+}
+
+// RecheckConfig configures the periodic checks of the links.
+type RecheckConfig struct {
+	// Interval is how often BatchSize links checked more than MaxAge ago are
+	// queued, changed at random by up to Jitter of it. Zero Interval
+	// disables the checks.
+	Interval  time.Duration `env:"INTERVAL,default=1m"`
+	Jitter    float64       `env:"JITTER,default=0.2"`
+	MaxAge    time.Duration `env:"MAX_AGE,default=168h"`
+	BatchSize int64         `env:"BATCH_SIZE,default=100"`
+	// BrokenAfter is the number of the checks failed in a row after which a
+	// link is broken.
+	BrokenAfter int `env:"BROKEN_AFTER,default=3"`
+}
+
+type OutboxConfig struct {
+	Interval  time.Duration `env:"INTERVAL,default=1s"`
+	BatchSize int64         `env:"BATCH_SIZE,default=100"`
+	// Retention is how long sent events are kept.
+	Retention time.Duration `env:"RETENTION,default=168h"`
+}
+
+// MongoConfig configures the connection to Mongo. Links are written in
+// transactions along with their events, so Mongo must be a replica set.
 type MongoConfig struct {
 	Name           string        `env:"NAME,default=links"`
 	Host           string        `env:"HOST,default=127.0.0.1"`
@@ -45,6 +133,10 @@ type MongoConfig struct {
 	MinPoolSize    uint64        `env:"MIN_POOL_SIZE,default=5"`
 	MaxPoolSize    uint64        `env:"MAX_POOL_SIZE,default=50"`
 	ConnectTimeout time.Duration `env:"CONNECT_TIMEOUT,default=5s"`
+	Timeout        time.Duration `env:"TIMEOUT,default=5s"`
+	ReplicaSet     string        `env:"REPLICA_SET"`
+	// Direct connects to the host only, without discovering the replica set.
+	Direct bool `env:"DIRECT,default=false"`
 }
 
 func (m MongoConfig) ConnectionString() string {
@@ -52,13 +144,34 @@ func (m MongoConfig) ConnectionString() string {
 }
 
 type UsersService struct {
-	Postgres   PostgresConfig  `env:",prefix=DB_"`
-	GRPCServer UsersGRPCConfig `env:",prefix=GRPC_"`
+	Postgres        PostgresConfig  `env:",prefix=DB_"`
+	GRPCServer      UsersGRPCConfig `env:",prefix=GRPC_"`
+	Password        PasswordConfig  `env:",prefix=PASSWORD_"`
+	Auth            AuthConfig      `env:",prefix=AUTH_"`
+	ShutdownTimeout time.Duration   `env:"SHUTDOWN_TIMEOUT,default=10s"`
+}
+
+type AuthConfig struct {
+	JWTKey     string        `env:"JWT_KEY" json:"-"`
+	Issuer     string        `env:"ISSUER,default=umanager"`
+	AccessTTL  time.Duration `env:"ACCESS_TTL,default=15m"`
+	RefreshTTL time.Duration `env:"REFRESH_TTL,default=720h"`
+}
+
+type PasswordConfig struct {
+	Algorithm         string `env:"ALGORITHM,default=argon2id"`
+	Argon2Memory      uint32 `env:"ARGON2_MEMORY,default=65536"`
+	Argon2Iterations  uint32 `env:"ARGON2_ITERATIONS,default=1"`
+	Argon2Parallelism uint8  `env:"ARGON2_PARALLELISM,default=4"`
+	Argon2SaltLength  uint32 `env:"ARGON2_SALT_LENGTH,default=16"`
+	Argon2KeyLength   uint32 `env:"ARGON2_KEY_LENGTH,default=32"`
+	BcryptCost        int    `env:"BCRYPT_COST,default=10"`
 }
 
 type UsersGRPCConfig struct {
-	Addr    string        `env:"ADDR,default=:52000"`
-	Timeout time.Duration `env:"TIMEOUT,default=10s"`
+	Addr           string        `env:"ADDR,default=:52000"`
+	Timeout        time.Duration `env:"TIMEOUT,default=10s"`
+	HealthInterval time.Duration `env:"HEALTH_INTERVAL,default=5s"`
 }
 
 type PostgresConfig struct {
@@ -104,9 +217,12 @@ func (c PostgresConfig) ConnectionURL() string {
 }
 
 type APIGWService struct {
-	Addr            string        `env:"ADDR,default=:8080"`
-	ReadTimeout     time.Duration `env:"READ_TIMEOUT,default=30s"`
-	WriteTimeout    time.Duration `env:"WRITE_TIMEOUT,default=30s"`
-	UsersClientAddr string        `env:"USERS_CLIENT_ADDR,default=:52000"`
-	LinksClientAddr string        `env:"LINKS_CLIENT_ADDR,default=:51000"`
+	Addr              string        `env:"ADDR,default=:8080"`
+	ReadTimeout       time.Duration `env:"READ_TIMEOUT,default=30s"`
+	WriteTimeout      time.Duration `env:"WRITE_TIMEOUT,default=30s"`
+	UsersClientAddr   string        `env:"USERS_CLIENT_ADDR,default=:52000"`
+	LinksClientAddr   string        `env:"LINKS_CLIENT_ADDR,default=:51000"`
+	ValidateResponses bool          `env:"VALIDATE_RESPONSES,default=false"`
+	ReadyTimeout      time.Duration `env:"READY_TIMEOUT,default=2s"`
+	ShutdownTimeout   time.Duration `env:"SHUTDOWN_TIMEOUT,default=10s"`
 }
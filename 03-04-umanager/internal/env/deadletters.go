@@ -0,0 +1,42 @@
+package env
+
+import (
+	"context"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/sethvargo/go-envconfig"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/env/config"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/link/stories/deadletters"
+)
+
+// SetupDeadLetters connects to RabbitMQ only, to manage the dead-lettered
+// link messages.
+func SetupDeadLetters(ctx context.Context) (_ *deadletters.Story, _ *Closer, err error) {
+	var cfg config.Links
+	if err := envconfig.Process(ctx, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("env processing: %w", err)
+	}
+
+	closer := &Closer{}
+	defer func() {
+		if err != nil {
+			closer.Close(context.Background())
+		}
+	}()
+
+	amqpConn, err := amqp.Dial(cfg.LinksService.AMQP.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("amqp Dial: %w", err)
+	}
+	closer.add("amqp connection", func(context.Context) error { return amqpConn.Close() })
+
+	amqpChannel, err := amqpConn.Channel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("amqp Channel: %w", err)
+	}
+	closer.add("amqp channel", func(context.Context) error { return amqpChannel.Close() })
+
+	return deadletters.New(amqpChannel, cfg.LinksService.AMQP.QueueName), closer, nil
+}
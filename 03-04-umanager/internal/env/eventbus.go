@@ -0,0 +1,60 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/env/config"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus/memory"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus/rabbitmq"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/health"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/amqpconn"
+)
+
+// setupEventBus returns the event bus selected by cfg along with the health
+// checks of its broker.
+func setupEventBus(cfg config.LinksService, closer *Closer) (eventbus.Bus, map[string]health.Check, error) {
+	switch cfg.EventBus {
+	case config.EventBusMemory:
+		return memory.New(), nil, nil
+	case config.EventBusRabbitMQ:
+	default:
+		return nil, nil, fmt.Errorf("unknown event bus %q", cfg.EventBus)
+	}
+
+	amqpManager, err := amqpconn.Dial(amqpconn.Config{
+		URL:        cfg.AMQP.String(),
+		MinBackoff: cfg.AMQP.ReconnectMin,
+		MaxBackoff: cfg.AMQP.ReconnectMax,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("amqpconn Dial: %w", err)
+	}
+	closer.add("amqp connection", func(context.Context) error { return amqpManager.Close() })
+
+	amqpChannel, err := amqpManager.Channel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("amqp Channel: %w", err)
+	}
+
+	publishChannel, err := amqpManager.ConfirmChannel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("amqp ConfirmChannel: %w", err)
+	}
+
+	checks := map[string]health.Check{
+		"amqp": func(context.Context) error {
+			if !amqpManager.IsConnected() {
+				return amqpconn.ErrDisconnected
+			}
+			if amqpChannel.IsClosed() || publishChannel.IsClosed() {
+				return errors.New("amqp channel is closed")
+			}
+			return nil
+		},
+	}
+
+	return rabbitmq.New(amqpManager, publishChannel, amqpChannel, cfg.AMQP.Exchange), checks, nil
+}
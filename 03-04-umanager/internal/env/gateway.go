@@ -0,0 +1,100 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/sethvargo/go-envconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/apigw/routes"
+	v1 "github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/apigw/v1"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/env/config"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/health"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
+)
+
+// Gateway is the api-gw: an HTTP server in front of the gRPC services.
+type Gateway struct {
+	Config     config.Gateway
+	HTTPServer *http.Server
+}
+
+// SetupGateway opens client connections to the users and links services. The
+// connections are established lazily, so the services may start later.
+func SetupGateway(ctx context.Context) (_ *Gateway, _ *Closer, err error) {
+	var cfg config.Gateway
+	if err := envconfig.Process(ctx, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("env processing: %w", err)
+	}
+
+	if cfg.Auth.JWTKey == "" {
+		return nil, nil, errors.New("env processing: USERS_AUTH_JWT_KEY is required")
+	}
+
+	closer := &Closer{}
+	defer func() {
+		if err != nil {
+			closer.Close(context.Background())
+		}
+	}()
+
+	authTokens := auth.NewTokens([]byte(cfg.Auth.JWTKey), cfg.Auth.Issuer, cfg.Auth.AccessTTL)
+
+	usersClientConn, err := grpc.DialContext(
+		ctx, cfg.APIGWService.UsersClientAddr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("grpc DialContext: %w", err)
+	}
+	closer.add("users client", func(context.Context) error { return usersClientConn.Close() })
+
+	usersClient := pb.NewUserServiceClient(usersClientConn)
+
+	linksClientConn, err := grpc.DialContext(
+		ctx, cfg.APIGWService.LinksClientAddr, grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("grpc DialContext: %w", err)
+	}
+	closer.add("links client", func(context.Context) error { return linksClientConn.Close() })
+
+	linksClient := pb.NewLinkServiceClient(linksClientConn)
+
+	handler := v1.New(usersClient, linksClient)
+	validate, err := v1.Validate(v1.ValidationOptions{
+		BasePath:          routes.BasePath,
+		ValidateResponses: cfg.APIGWService.ValidateResponses,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("v1 Validate: %w", err)
+	}
+
+	router := routes.Router(handler, validate, v1.Authenticate(authTokens, usersClient))
+
+	probes := health.NewProbes(
+		map[string]grpc_health_v1.HealthClient{
+			"users": grpc_health_v1.NewHealthClient(usersClientConn),
+			"links": grpc_health_v1.NewHealthClient(linksClientConn),
+		},
+		cfg.APIGWService.ReadyTimeout,
+	)
+	router.Get("/healthz", probes.Live)
+	router.Get("/readyz", probes.Ready)
+
+	apiGWServer := &http.Server{
+		Addr:              cfg.APIGWService.Addr,
+		Handler:           router,
+		ReadTimeout:       cfg.APIGWService.ReadTimeout,
+		ReadHeaderTimeout: cfg.APIGWService.ReadTimeout,
+		WriteTimeout:      cfg.APIGWService.WriteTimeout,
+		IdleTimeout:       cfg.APIGWService.ReadTimeout,
+	}
+
+	return &Gateway{Config: cfg, HTTPServer: apiGWServer}, closer, nil
+}
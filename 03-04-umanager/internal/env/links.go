@@ -0,0 +1,192 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/sethvargo/go-envconfig"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database/links"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database/outbox"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/env/config"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/grpcerr"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/health"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/link/linkgrpc"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/link/stories/linkscheduler"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/link/stories/linkupdater"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/link/stories/outboxrelay"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/scrape"
+)
+
+// Links is the links service: a gRPC server backed by Mongo, the updater
+// consuming the link events from the event bus and the scheduler of the
+// periodic checks of the links. The events are written to the
+// outbox along with the links and published by the relay, so they wait in the
+// outbox while the broker is unavailable.
+type Links struct {
+	Config      config.Links
+	GRPCServer  *grpc.Server
+	Health      *health.Checker
+	LinkUpdater *linkupdater.Story
+	// LinkScheduler queues the links for the updater to check them again.
+	LinkScheduler *linkscheduler.Story
+	OutboxRelay   *outboxrelay.Story
+}
+
+// SetupLinks connects to Mongo and to RabbitMQ unless the event bus is in
+// memory.
+func SetupLinks(ctx context.Context) (_ *Links, _ *Closer, err error) {
+	var cfg config.Links
+	if err := envconfig.Process(ctx, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("env processing: %w", err)
+	}
+
+	closer := &Closer{}
+	defer func() {
+		if err != nil {
+			closer.Close(context.Background())
+		}
+	}()
+
+	mongoOptions := &options.ClientOptions{
+		ConnectTimeout: &cfg.LinksService.Mongo.ConnectTimeout,
+		Hosts:          []string{fmt.Sprintf("%s:%d", cfg.LinksService.Mongo.Host, cfg.LinksService.Mongo.Port)},
+		MaxPoolSize:    &cfg.LinksService.Mongo.MaxPoolSize,
+		MinPoolSize:    &cfg.LinksService.Mongo.MinPoolSize,
+		Direct:         &cfg.LinksService.Mongo.Direct,
+	}
+	if cfg.LinksService.Mongo.ReplicaSet != "" {
+		mongoOptions.SetReplicaSet(cfg.LinksService.Mongo.ReplicaSet)
+	}
+
+	linksDBConn, err := mongo.Connect(ctx, mongoOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("mongo.Connect: %w", err)
+	}
+	closer.add("mongo", linksDBConn.Disconnect)
+
+	bus, busChecks, err := setupEventBus(cfg.LinksService, closer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	linksRepository := links.New(
+		linksDBConn.Database(cfg.LinksService.Mongo.Name),
+		cfg.LinksService.Mongo.Timeout,
+	)
+	if err := linksRepository.EnsureIndexes(ctx); err != nil {
+		return nil, nil, fmt.Errorf("links repository EnsureIndexes: %w", err)
+	}
+
+	outboxRepository := outbox.New(
+		linksDBConn.Database(cfg.LinksService.Mongo.Name),
+		cfg.LinksService.Mongo.Timeout,
+	)
+	if err := outboxRepository.EnsureIndexes(ctx, cfg.LinksService.Outbox.Retention); err != nil {
+		return nil, nil, fmt.Errorf("outbox repository EnsureIndexes: %w", err)
+	}
+
+	scraper, err := newScraper(cfg.LinksService.Scrape)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updater := linkupdater.New(linksRepository, bus, scraper, linkupdater.Config{
+		Subscription: eventbus.Subscription{
+			Queue:         cfg.LinksService.AMQP.QueueName,
+			Workers:       cfg.LinksService.AMQP.Workers,
+			MaxAttempts:   cfg.LinksService.AMQP.MaxAttempts,
+			RetryDelay:    cfg.LinksService.AMQP.RetryDelay,
+			DrainTimeout:  cfg.LinksService.ShutdownTimeout,
+			StatsInterval: cfg.LinksService.AMQP.StatsInterval,
+		},
+		HostRate:        cfg.LinksService.AMQP.HostRate,
+		HostBurst:       cfg.LinksService.AMQP.HostBurst,
+		HostConcurrency: cfg.LinksService.AMQP.HostConcurrency,
+		BrokenAfter:     cfg.LinksService.Recheck.BrokenAfter,
+	})
+	// the queue is declared before the relay starts, so that no event is
+	// missed by the updater
+	if err := bus.Declare(updater.Subscription()); err != nil {
+		return nil, nil, fmt.Errorf("event bus Declare: %w", err)
+	}
+
+	handler := linkgrpc.New(linksRepository, cfg.LinksService.GRPCServer.Timeout)
+
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		grpcerr.UnaryServerInterceptor(),
+		auth.UnaryServerInterceptor(linkgrpc.Permissions),
+	))
+	reflection.Register(s)
+	pb.RegisterLinkServiceServer(s, handler)
+
+	healthServer := grpchealth.NewServer()
+	grpc_health_v1.RegisterHealthServer(s, healthServer)
+
+	checks := map[string]health.Check{
+		"mongo": func(ctx context.Context) error { return linksDBConn.Ping(ctx, readpref.Primary()) },
+	}
+	for name, check := range busChecks {
+		checks[name] = check
+	}
+
+	checker := health.New(
+		healthServer,
+		[]string{pb.LinkService_ServiceDesc.ServiceName},
+		checks,
+		cfg.LinksService.GRPCServer.HealthInterval,
+	)
+
+	return &Links{
+		Config:      cfg,
+		GRPCServer:  s,
+		Health:      checker,
+		LinkUpdater: updater,
+		LinkScheduler: linkscheduler.New(linksRepository, linkscheduler.Config{
+			Interval:  cfg.LinksService.Recheck.Interval,
+			Jitter:    cfg.LinksService.Recheck.Jitter,
+			MaxAge:    cfg.LinksService.Recheck.MaxAge,
+			BatchSize: cfg.LinksService.Recheck.BatchSize,
+		}),
+		OutboxRelay: outboxrelay.New(outboxRepository, bus, outboxrelay.Config{
+			Interval:  cfg.LinksService.Outbox.Interval,
+			BatchSize: cfg.LinksService.Outbox.BatchSize,
+		}),
+	}, closer, nil
+}
+
+func newScraper(cfg config.ScrapeConfig) (*scrape.Client, error) {
+	networks := make([]netip.Prefix, 0, len(cfg.AllowNetworks))
+	for _, n := range cfg.AllowNetworks {
+		p, err := netip.ParsePrefix(n)
+		if err != nil {
+			return nil, fmt.Errorf("scrape allowed network: %w", err)
+		}
+		networks = append(networks, p)
+	}
+
+	return scrape.New(scrape.Config{
+		ConnectTimeout:  cfg.ConnectTimeout,
+		ReadTimeout:     cfg.ReadTimeout,
+		MaxBodySize:     cfg.MaxBodySize,
+		MaxRedirects:    cfg.MaxRedirects,
+		ContentTypes:    cfg.ContentTypes,
+		AllowedHosts:    cfg.AllowHosts,
+		AllowedNetworks: networks,
+		UserAgent:       cfg.UserAgent,
+		ContactURL:      cfg.ContactURL,
+		RobotsTTL:       cfg.RobotsTTL,
+		MaxCrawlDelay:   cfg.MaxCrawlDelay,
+	}), nil
+}
@@ -0,0 +1,46 @@
+package env
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+)
+
+// Shutdown stops the users gRPC server, waiting for the in-flight calls
+// until ctx is done.
+func (u *Users) Shutdown(ctx context.Context) {
+	gracefulStop(ctx, u.GRPCServer)
+}
+
+// Shutdown stops the links gRPC server, waiting for the in-flight calls
+// until ctx is done. The link updater stops on its own when the context
+// passed to its Run is done.
+func (l *Links) Shutdown(ctx context.Context) {
+	gracefulStop(ctx, l.GRPCServer)
+}
+
+// Shutdown stops the api-gw, waiting for the in-flight requests until ctx is
+// done.
+func (g *Gateway) Shutdown(ctx context.Context) {
+	if err := g.HTTPServer.Shutdown(ctx); err != nil {
+		slog.Error("api-gw http Shutdown", slog.Any("err", err))
+		_ = g.HTTPServer.Close()
+	}
+}
+
+func gracefulStop(ctx context.Context, s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		slog.Error("grpc GracefulStop", slog.Any("err", ctx.Err()))
+		s.Stop()
+		<-done
+	}
+}
@@ -0,0 +1,110 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/sethvargo/go-envconfig"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database/apikeys"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database/tokens"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database/users"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/env/config"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/grpcerr"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/health"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/user/usergrpc"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/password"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
+)
+
+// Users is the users service: a gRPC server backed by Postgres.
+type Users struct {
+	Config     config.Users
+	GRPCServer *grpc.Server
+	Health     *health.Checker
+}
+
+// SetupUsers connects to Postgres only.
+func SetupUsers(ctx context.Context) (_ *Users, _ *Closer, err error) {
+	var cfg config.Users
+	if err := envconfig.Process(ctx, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("env processing: %w", err)
+	}
+
+	if cfg.UsersService.Auth.JWTKey == "" {
+		return nil, nil, errors.New("env processing: USERS_AUTH_JWT_KEY is required")
+	}
+
+	closer := &Closer{}
+	defer func() {
+		if err != nil {
+			closer.Close(context.Background())
+		}
+	}()
+
+	usersDBConn, err := pgxpool.Connect(ctx, cfg.UsersService.Postgres.ConnectionURL())
+	if err != nil {
+		return nil, nil, fmt.Errorf("pgxpool Connect: %w", err)
+	}
+	closer.add("postgres", func(context.Context) error {
+		usersDBConn.Close()
+		return nil
+	})
+
+	authTokens := auth.NewTokens(
+		[]byte(cfg.UsersService.Auth.JWTKey), cfg.UsersService.Auth.Issuer, cfg.UsersService.Auth.AccessTTL,
+	)
+
+	hasher, err := password.New(password.Config{
+		Algorithm: cfg.UsersService.Password.Algorithm,
+		Argon2id: password.Argon2idParams{
+			Memory:      cfg.UsersService.Password.Argon2Memory,
+			Iterations:  cfg.UsersService.Password.Argon2Iterations,
+			Parallelism: cfg.UsersService.Password.Argon2Parallelism,
+			SaltLength:  cfg.UsersService.Password.Argon2SaltLength,
+			KeyLength:   cfg.UsersService.Password.Argon2KeyLength,
+		},
+		BcryptCost: cfg.UsersService.Password.BcryptCost,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("password New: %w", err)
+	}
+
+	handler := usergrpc.New(
+		users.New(usersDBConn, cfg.UsersService.Postgres.DBTimeout),
+		tokens.New(usersDBConn, cfg.UsersService.Postgres.DBTimeout),
+		apikeys.New(usersDBConn, cfg.UsersService.Postgres.DBTimeout),
+		hasher,
+		authTokens,
+		cfg.UsersService.Auth.RefreshTTL,
+		cfg.UsersService.GRPCServer.Timeout,
+	)
+
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		grpcerr.UnaryServerInterceptor(),
+		auth.UnaryServerInterceptor(usergrpc.Permissions),
+	))
+	reflection.Register(s)
+	pb.RegisterUserServiceServer(s, handler)
+
+	healthServer := grpchealth.NewServer()
+	grpc_health_v1.RegisterHealthServer(s, healthServer)
+
+	checker := health.New(
+		healthServer,
+		[]string{pb.UserService_ServiceDesc.ServiceName},
+		map[string]health.Check{
+			"postgres": func(ctx context.Context) error { return usersDBConn.Ping(ctx) },
+		},
+		cfg.UsersService.GRPCServer.HealthInterval,
+	)
+
+	return &Users{Config: cfg, GRPCServer: s, Health: checker}, closer, nil
+}
@@ -0,0 +1,56 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Dispatch runs sub.Workers workers handling the deliveries until ctx is done
+// or deliveries is closed, and returns once they stop. The deliveries being
+// handled when ctx is done are not interrupted, but the ctx passed to handle
+// is canceled after sub.DrainTimeout. The ones received after ctx is done are
+// passed to requeue.
+func Dispatch[D any](
+	ctx context.Context,
+	sub Subscription,
+	deliveries <-chan D,
+	handle func(ctx context.Context, d D),
+	requeue func(d D),
+) {
+	handleCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() {
+		time.AfterFunc(sub.DrainTimeout, cancel)
+	})
+	defer stop()
+
+	workers := max(sub.Workers, 1)
+
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case d, ok := <-deliveries:
+					if !ok {
+						return
+					}
+					if ctx.Err() != nil {
+						requeue(d)
+						return
+					}
+
+					handle(handleCtx, d)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,97 @@
+// Package eventbus carries the events between the parts of the links service.
+// The implementations share the delivery semantics: an event is delivered to
+// the queues of the subscriptions bound to its key, handled at least once,
+// retried with backoff when the handler fails and dead-lettered after
+// MaxAttempts attempts.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrPermanent marks the handler errors that retries cannot fix. The events
+// failed with it are dead-lettered at once.
+var ErrPermanent = errors.New("permanent error")
+
+// Event is a message on the bus.
+type Event struct {
+	ID string
+	// Key routes the event to the subscriptions. It is the type of the event.
+	Key         string
+	ContentType string
+	Body        []byte
+	Time        time.Time
+	// Attempts is the number of failed attempts to handle the event.
+	Attempts int
+}
+
+// Handler handles a delivered event. The event is acked when it returns nil
+// and retried otherwise. ctx is canceled once the drain timeout of a stopped
+// subscription expires; the event is then delivered again.
+type Handler func(ctx context.Context, e Event) error
+
+// Subscription describes a queue of events and how it is consumed.
+type Subscription struct {
+	Queue string
+	// Keys are the keys of the events routed to the queue. Like in the topic
+	// exchanges of RabbitMQ, "*" matches a word and "#" any number of words.
+	Keys []string
+	// Workers is the number of events handled at once.
+	Workers int
+	// MaxAttempts is the number of attempts to handle an event before it is
+	// dead-lettered.
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry. It doubles with every
+	// following retry.
+	RetryDelay time.Duration
+	// DrainTimeout is how long the events being handled are waited for once
+	// the subscription is stopped.
+	DrainTimeout time.Duration
+	// StatsInterval is how often the queue depth and the latency are logged.
+	// Zero disables the stats.
+	StatsInterval time.Duration
+}
+
+// Backoff returns the delay before the attempt-th retry.
+func (s Subscription) Backoff(attempt int) time.Duration {
+	return s.RetryDelay << (attempt - 1)
+}
+
+type Bus interface {
+	// Publish returns once the bus has accepted e.
+	Publish(ctx context.Context, e Event) error
+	// Declare creates the queue of sub, so that the events are kept until
+	// the queue is subscribed to.
+	Declare(sub Subscription) error
+	// Subscribe handles the events of sub until ctx is done. Then it stops
+	// receiving the events, waits for the ones being handled and returns nil.
+	Subscribe(ctx context.Context, sub Subscription, handler Handler) error
+}
+
+// Outcome is what becomes of a handled event.
+type Outcome int
+
+const (
+	Ack Outcome = iota
+	Retry
+	DeadLetter
+	Requeue
+)
+
+// Decide returns the outcome of the attempt-th attempt to handle an event,
+// failed with err. ctx is the context the handler was called with.
+func Decide(ctx context.Context, sub Subscription, attempt int, err error) Outcome {
+	switch {
+	case err == nil:
+		return Ack
+	case ctx.Err() != nil:
+		// interrupted by the drain timeout, so let it be handled again
+		return Requeue
+	case attempt >= sub.MaxAttempts || errors.Is(err, ErrPermanent):
+		return DeadLetter
+	default:
+		return Retry
+	}
+}
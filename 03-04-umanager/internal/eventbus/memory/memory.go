@@ -0,0 +1,234 @@
+// Package memory implements the event bus in the process, for tests and for
+// running the services without RabbitMQ. Its queues are not durable: the
+// events queued or waiting for a retry are lost when the process exits.
+package memory
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus"
+)
+
+var _ eventbus.Bus = (*Bus)(nil)
+
+func New() *Bus {
+	return &Bus{queues: map[string]*queue{}}
+}
+
+type Bus struct {
+	mu     sync.Mutex
+	queues map[string]*queue
+}
+
+// Publish routes e to the declared queues bound to its key. Like an
+// unroutable message in RabbitMQ, it is dropped if there are none.
+func (b *Bus) Publish(_ context.Context, e eventbus.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e.Attempts = 0
+	for _, q := range b.queues {
+		if q.bound(e.Key) {
+			q.push(e)
+		}
+	}
+
+	return nil
+}
+
+func (b *Bus) Declare(sub eventbus.Subscription) error {
+	b.declare(sub)
+	return nil
+}
+
+func (b *Bus) declare(sub eventbus.Subscription) *queue {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q, ok := b.queues[sub.Queue]
+	if !ok {
+		q = &queue{signal: make(chan struct{}, 1)}
+		b.queues[sub.Queue] = q
+	}
+	q.keys = sub.Keys
+
+	return q
+}
+
+// DeadLetters returns the events dead-lettered from the queue.
+func (b *Bus) DeadLetters(queue string) []eventbus.Event {
+	b.mu.Lock()
+	q, ok := b.queues[queue]
+	b.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]eventbus.Event(nil), q.dead...)
+}
+
+// Subscribe handles the events of the queue of sub with sub.Workers workers.
+// The events retried are queued again after their backoff.
+func (b *Bus) Subscribe(ctx context.Context, sub eventbus.Subscription, handler eventbus.Handler) error {
+	q := b.declare(sub)
+
+	statsCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stats := &eventbus.Stats{}
+	handler = stats.Observe(handler)
+	if sub.StatsInterval > 0 {
+		go stats.Report(statsCtx, sub, func() (int, error) { return q.len(), nil })
+	}
+
+	deliveries := make(chan eventbus.Event)
+	go q.deliver(ctx, deliveries)
+
+	eventbus.Dispatch(ctx, sub, deliveries, func(ctx context.Context, e eventbus.Event) {
+		handle(ctx, sub, q, handler, e)
+	}, q.requeue)
+
+	return nil
+}
+
+func handle(ctx context.Context, sub eventbus.Subscription, q *queue, handler eventbus.Handler, e eventbus.Event) {
+	err := handler(ctx, e)
+	attempt := e.Attempts + 1
+
+	switch eventbus.Decide(ctx, sub, attempt, err) {
+	case eventbus.Ack:
+	case eventbus.Requeue:
+		slog.Warn("handle event interrupted", slog.Any("err", err))
+		q.requeue(e)
+	case eventbus.DeadLetter:
+		slog.Error("event is dead-lettered", slog.Int("attempt", attempt), slog.Any("err", err))
+		e.Attempts = attempt
+		q.deadLetter(e)
+	case eventbus.Retry:
+		slog.Warn("event is retried", slog.Int("attempt", attempt), slog.Any("err", err))
+		e.Attempts = attempt
+		time.AfterFunc(sub.Backoff(attempt), func() { q.push(e) })
+	}
+}
+
+type queue struct {
+	mu      sync.Mutex
+	keys    []string
+	pending []eventbus.Event
+	dead    []eventbus.Event
+	// signal wakes up the delivery once an event is pushed.
+	signal chan struct{}
+}
+
+func (q *queue) bound(key string) bool {
+	for _, k := range q.keys {
+		if match(strings.Split(k, "."), strings.Split(key, ".")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (q *queue) push(e eventbus.Event) {
+	q.mu.Lock()
+	q.pending = append(q.pending, e)
+	q.mu.Unlock()
+
+	q.wake()
+}
+
+// requeue puts e back at the head of the queue.
+func (q *queue) requeue(e eventbus.Event) {
+	q.mu.Lock()
+	q.pending = append([]eventbus.Event{e}, q.pending...)
+	q.mu.Unlock()
+
+	q.wake()
+}
+
+func (q *queue) deadLetter(e eventbus.Event) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.dead = append(q.dead, e)
+}
+
+func (q *queue) wake() {
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (q *queue) pop() (eventbus.Event, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == 0 {
+		return eventbus.Event{}, false
+	}
+	e := q.pending[0]
+	q.pending = q.pending[1:]
+
+	return e, true
+}
+
+func (q *queue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.pending)
+}
+
+// deliver sends the queued events to deliveries until ctx is done.
+func (q *queue) deliver(ctx context.Context, deliveries chan<- eventbus.Event) {
+	for {
+		e, ok := q.pop()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.signal:
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			q.requeue(e)
+			return
+		case deliveries <- e:
+		}
+	}
+}
+
+// match reports whether the words of a key match the words of a binding key
+// with the wildcards of the topic exchanges.
+func match(pattern, key []string) bool {
+	if len(pattern) == 0 {
+		return len(key) == 0
+	}
+
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(key); i++ {
+			if match(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(key) > 0 && match(pattern[1:], key[1:])
+	default:
+		return len(key) > 0 && pattern[0] == key[0] && match(pattern[1:], key[1:])
+	}
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/eventbus"
+)
+
+func TestBusSubscribe(t *testing.T) {
+	failure := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		key      string
+		failures int
+		err      error
+		calls    int
+		dead     int
+	}{
+		{
+			name:  "test_handled_event",
+			key:   "link.created",
+			calls: 1,
+		},
+		{
+			name:     "test_failed_event_is_retried",
+			key:      "link.updated",
+			failures: 2,
+			err:      failure,
+			calls:    3,
+		},
+		{
+			name:     "test_event_over_max_attempts_is_dead_lettered",
+			key:      "link.created",
+			failures: 5,
+			err:      failure,
+			calls:    3,
+			dead:     3,
+		},
+		{
+			name:     "test_permanent_failure_is_dead_lettered",
+			key:      "link.created",
+			failures: 1,
+			err:      fmt.Errorf("%w: json Unmarshal", eventbus.ErrPermanent),
+			calls:    1,
+			dead:     1,
+		},
+		{
+			name: "test_unbound_event_is_dropped",
+			key:  "link.deleted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New()
+			sub := eventbus.Subscription{
+				Queue:       "links",
+				Keys:        []string{"link.created", "link.updated"},
+				Workers:     2,
+				MaxAttempts: 3,
+				RetryDelay:  time.Millisecond,
+			}
+			if err := b.Declare(sub); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Publish(context.Background(), eventbus.Event{ID: "1", Key: tt.key}); err != nil {
+				t.Fatal(err)
+			}
+
+			var calls atomic.Int64
+			handled := make(chan struct{}, 10)
+			handler := func(_ context.Context, e eventbus.Event) error {
+				defer func() { handled <- struct{}{} }()
+				if e.ID != "1" || e.Key != tt.key {
+					t.Errorf("event = %+v", e)
+				}
+				if int(calls.Add(1)) <= tt.failures {
+					return tt.err
+				}
+				return nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() { done <- b.Subscribe(ctx, sub, handler) }()
+
+			for i := 0; i < tt.calls; i++ {
+				select {
+				case <-handled:
+				case <-time.After(5 * time.Second):
+					t.Fatalf("handled %d events, want %d", calls.Load(), tt.calls)
+				}
+			}
+			// no more deliveries are expected
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+			if err := <-done; err != nil {
+				t.Fatalf("Subscribe() error = %v", err)
+			}
+
+			if got := int(calls.Load()); got != tt.calls {
+				t.Errorf("calls = %d, want %d", got, tt.calls)
+			}
+			dead := b.DeadLetters("links")
+			if tt.dead == 0 {
+				if len(dead) != 0 {
+					t.Errorf("dead letters = %+v, want none", dead)
+				}
+				return
+			}
+			if len(dead) != 1 || dead[0].Attempts != tt.dead {
+				t.Errorf("dead letters = %+v, want one with %d attempts", dead, tt.dead)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{pattern: "link.created", key: "link.created", want: true},
+		{pattern: "link.created", key: "link.updated", want: false},
+		{pattern: "link.*", key: "link.updated", want: true},
+		{pattern: "link.*", key: "link", want: false},
+		{pattern: "link.#", key: "link", want: true},
+		{pattern: "#", key: "link.enriched", want: true},
+		{pattern: "*.enriched", key: "link.enriched", want: true},
+		{pattern: "#.enriched", key: "link.user.enriched", want: true},
+	}
+	for _, tt := range tests {
+		t.Run("test_"+tt.pattern+"_"+tt.key, func(t *testing.T) {
+			if got := match(strings.Split(tt.pattern, "."), strings.Split(tt.key, ".")); got != tt.want {
+				t.Errorf("match(%q, %q) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+			}
+		})
+	}
+}
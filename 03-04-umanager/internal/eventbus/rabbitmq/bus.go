@@ -0,0 +1,201 @@
+// Package rabbitmq implements the event bus with a topic exchange. Every
+// subscription has a queue bound to the exchange, retry queues delaying the
+// failed messages with their TTL and a dead-letter queue.
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/amqpconn"
+)
+
+var _ eventbus.Bus = (*Bus)(nil)
+
+// New returns the bus publishing to exchange through publisher and consuming
+// through channel.
+func New(conn connection, publisher amqpConfirmPublisher, channel amqpChannel, exchange string) *Bus {
+	return &Bus{
+		conn:      conn,
+		publisher: publisher,
+		channel:   channel,
+		exchange:  exchange,
+	}
+}
+
+type Bus struct {
+	conn      connection
+	publisher amqpConfirmPublisher
+	channel   amqpChannel
+	exchange  string
+}
+
+// Publish returns once the broker confirms e. The message is persistent, so
+// it is not lost when the broker restarts before it is consumed.
+func (b *Bus) Publish(ctx context.Context, e eventbus.Event) error {
+	confirmation, err := b.publisher.PublishWithDeferredConfirmWithContext(
+		ctx, b.exchange, e.Key, false, false, amqp.Publishing{
+			MessageId:    e.ID,
+			ContentType:  e.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Body:         e.Body,
+			Timestamp:    e.Time,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("amqp Publish: %w", err)
+	}
+	if confirmation == nil {
+		return errors.New("amqp channel is not in confirm mode")
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("amqp confirmation: %w", err)
+	}
+	if !acked {
+		return errors.New("event is nacked by the broker")
+	}
+
+	return nil
+}
+
+// Declare declares the queues of sub, again after every reconnection as the
+// broker may have lost them.
+func (b *Bus) Declare(sub eventbus.Subscription) error {
+	return b.conn.Declare(func(ch amqpconn.Declarer) error {
+		return DeclareQueues(ch, b.exchange, sub)
+	})
+}
+
+// Subscribe consumes the queue of sub with sub.Workers workers. The prefetch
+// matches the workers, so the messages are not held by a busy consumer.
+// Messages are acked after handling, so the ones delivered but not handled
+// yet go back to the queue when Subscribe stops.
+func (b *Bus) Subscribe(ctx context.Context, sub eventbus.Subscription, handler eventbus.Handler) error {
+	sub.Workers = max(sub.Workers, 1)
+
+	if err := b.channel.Qos(sub.Workers, 0, false); err != nil {
+		return fmt.Errorf("amqp Qos: %w", err)
+	}
+
+	ch, err := b.channel.Consume(sub.Queue, sub.Queue, false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("amqp Consume: %w", err)
+	}
+
+	statsCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stats := &eventbus.Stats{}
+	handler = stats.Observe(handler)
+	if sub.StatsInterval > 0 {
+		go stats.Report(statsCtx, sub, func() (int, error) {
+			q, err := b.channel.QueueDeclarePassive(sub.Queue, false, false, false, false, nil)
+			return q.Messages, err
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		eventbus.Dispatch(ctx, sub, ch, func(ctx context.Context, m amqp.Delivery) {
+			b.handle(ctx, sub, handler, m)
+		}, requeue)
+	}()
+
+	select {
+	case <-ctx.Done():
+	case <-done:
+		if ctx.Err() == nil {
+			return errors.New("rabbitmq queue is closed")
+		}
+	}
+
+	if err := b.channel.Cancel(sub.Queue, false); err != nil {
+		<-done
+		return fmt.Errorf("amqp Cancel: %w", err)
+	}
+	<-done
+
+	return nil
+}
+
+func (b *Bus) handle(ctx context.Context, sub eventbus.Subscription, handler eventbus.Handler, m amqp.Delivery) {
+	e := eventbus.Event{
+		ID:          m.MessageId,
+		Key:         m.RoutingKey,
+		ContentType: m.ContentType,
+		Body:        m.Body,
+		Time:        m.Timestamp,
+		Attempts:    Attempts(m.Headers),
+	}
+	if key, ok := m.Headers[KeyHeader].(string); ok {
+		e.Key = key
+	}
+
+	err := handler(ctx, e)
+	attempt := e.Attempts + 1
+
+	switch eventbus.Decide(ctx, sub, attempt, err) {
+	case eventbus.Ack:
+	case eventbus.Requeue:
+		slog.Warn("handle message interrupted", slog.Any("err", err))
+		requeue(m)
+		return
+	case eventbus.DeadLetter:
+		slog.Error("message is dead-lettered", slog.Int("attempt", attempt), slog.Any("err", err))
+		if err := b.republish(m, e.Key, DeadLetterQueue(sub.Queue), attempt, err); err != nil {
+			slog.Error("dead-letter message", slog.Any("err", err))
+			requeue(m)
+			return
+		}
+	case eventbus.Retry:
+		slog.Warn("message is retried", slog.Int("attempt", attempt), slog.Any("err", err))
+		if err := b.republish(m, e.Key, RetryQueue(sub.Queue, sub.Backoff(attempt)), attempt, err); err != nil {
+			slog.Error("retry message", slog.Any("err", err))
+			requeue(m)
+			return
+		}
+	}
+
+	if err := m.Ack(false); err != nil {
+		slog.Error("amqp Ack", slog.Any("err", err))
+	}
+}
+
+// republish publishes the failed message to queue with its attempt and error.
+func (b *Bus) republish(m amqp.Delivery, key, queue string, attempt int, cause error) error {
+	headers := amqp.Table{}
+	for k, v := range m.Headers {
+		headers[k] = v
+	}
+	headers[AttemptHeader] = int32(attempt)
+	headers[ErrorHeader] = cause.Error()
+	headers[KeyHeader] = key
+
+	err := b.channel.Publish("", queue, false, false, amqp.Publishing{
+		Headers:      headers,
+		MessageId:    m.MessageId,
+		ContentType:  m.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Body:         m.Body,
+		Timestamp:    m.Timestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("amqp Publish %s: %w", queue, err)
+	}
+
+	return nil
+}
+
+func requeue(m amqp.Delivery) {
+	if err := m.Nack(false, true); err != nil {
+		slog.Error("amqp Nack", slog.Any("err", err))
+	}
+}
@@ -0,0 +1,251 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/eventbus"
+)
+
+type fakeChannel struct {
+	deliveries chan amqp.Delivery
+	canceled   chan string
+	published  map[string]amqp.Publishing
+}
+
+func (c fakeChannel) Consume(string, string, bool, bool, bool, bool, amqp.Table) (<-chan amqp.Delivery, error) {
+	return c.deliveries, nil
+}
+
+func (c fakeChannel) Cancel(consumer string, _ bool) error {
+	c.canceled <- consumer
+	return nil
+}
+
+func (c fakeChannel) Qos(int, int, bool) error {
+	return nil
+}
+
+func (c fakeChannel) QueueDeclarePassive(name string, _, _, _, _ bool, _ amqp.Table) (amqp.Queue, error) {
+	return amqp.Queue{Name: name}, nil
+}
+
+func (c fakeChannel) Publish(_, key string, _, _ bool, msg amqp.Publishing) error {
+	c.published[key] = msg
+	return nil
+}
+
+func (c fakeChannel) PublishWithDeferredConfirmWithContext(
+	context.Context, string, string, bool, bool, amqp.Publishing,
+) (*amqp.DeferredConfirmation, error) {
+	return nil, nil
+}
+
+type fakeAcknowledger struct {
+	mu      sync.Mutex
+	acked   bool
+	requeue bool
+}
+
+func (a *fakeAcknowledger) Ack(uint64, bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.acked = true
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(_ uint64, _ bool, requeue bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.requeue = requeue
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(_ uint64, requeue bool) error {
+	return a.Nack(0, false, requeue)
+}
+
+func TestBusSubscribeDrain(t *testing.T) {
+	tests := []struct {
+		name         string
+		drainTimeout time.Duration
+		release      bool
+		acked        bool
+		requeued     bool
+	}{
+		{
+			name:         "test_message_in_progress_is_finished_and_acked",
+			drainTimeout: time.Second,
+			release:      true,
+			acked:        true,
+		},
+		{
+			name:         "test_message_over_drain_timeout_is_requeued",
+			drainTimeout: 10 * time.Millisecond,
+			requeued:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel := fakeChannel{deliveries: make(chan amqp.Delivery, 1), canceled: make(chan string, 1)}
+			ack := &fakeAcknowledger{}
+			channel.deliveries <- amqp.Delivery{Acknowledger: ack, Body: []byte("{}")}
+
+			started, release := make(chan struct{}), make(chan struct{})
+			handler := func(ctx context.Context, _ eventbus.Event) error {
+				close(started)
+
+				select {
+				case <-release:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				sub := eventbus.Subscription{Queue: "links", DrainTimeout: tt.drainTimeout}
+				done <- New(nil, channel, channel, "links").Subscribe(ctx, sub, handler)
+			}()
+
+			<-started
+			cancel()
+			if tt.release {
+				close(release)
+			}
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("Subscribe() error = %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Subscribe() did not return")
+			}
+
+			if got := <-channel.canceled; got != "links" {
+				t.Errorf("canceled consumer = %q, want %q", got, "links")
+			}
+			if ack.acked != tt.acked {
+				t.Errorf("acked = %v, want %v", ack.acked, tt.acked)
+			}
+			if ack.requeue != tt.requeued {
+				t.Errorf("requeued = %v, want %v", ack.requeue, tt.requeued)
+			}
+		})
+	}
+}
+
+func TestBusRetry(t *testing.T) {
+	failure := errors.New("mongo FindOne: connection refused")
+
+	tests := []struct {
+		name      string
+		err       error
+		headers   amqp.Table
+		queue     string
+		attempt   int
+		published bool
+	}{
+		{
+			name:      "test_first_failure_is_retried",
+			err:       failure,
+			queue:     "links.retry.1000",
+			attempt:   1,
+			published: true,
+		},
+		{
+			name:      "test_later_failure_is_retried_with_next_attempt",
+			err:       failure,
+			headers:   amqp.Table{AttemptHeader: int32(2), KeyHeader: "link.created"},
+			queue:     "links.retry.4000",
+			attempt:   3,
+			published: true,
+		},
+		{
+			name:      "test_last_attempt_is_dead_lettered",
+			err:       failure,
+			headers:   amqp.Table{AttemptHeader: int32(4), KeyHeader: "link.created"},
+			queue:     "links.dead",
+			attempt:   5,
+			published: true,
+		},
+		{
+			name:      "test_permanent_failure_is_dead_lettered",
+			err:       fmt.Errorf("%w: json Unmarshal", eventbus.ErrPermanent),
+			queue:     "links.dead",
+			attempt:   1,
+			published: true,
+		},
+		{
+			name: "test_handled_message_is_acked",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel := fakeChannel{published: map[string]amqp.Publishing{}}
+			ack := &fakeAcknowledger{}
+			sub := eventbus.Subscription{Queue: "links", MaxAttempts: 5, RetryDelay: time.Second}
+
+			var got eventbus.Event
+			handler := func(_ context.Context, e eventbus.Event) error {
+				got = e
+				return tt.err
+			}
+
+			New(nil, channel, channel, "links").handle(context.Background(), sub, handler, amqp.Delivery{
+				Acknowledger: ack,
+				Headers:      tt.headers,
+				RoutingKey:   "link.created",
+				Body:         []byte("{}"),
+			})
+
+			if !ack.acked {
+				t.Errorf("message is not acked")
+			}
+			if got.Key != "link.created" {
+				t.Errorf("event key = %q, want link.created", got.Key)
+			}
+			if !tt.published {
+				if len(channel.published) != 0 {
+					t.Errorf("published = %v, want nothing", channel.published)
+				}
+				return
+			}
+
+			msg, ok := channel.published[tt.queue]
+			if !ok || len(channel.published) != 1 {
+				t.Fatalf("published = %v, want one message to %s", channel.published, tt.queue)
+			}
+			if msg.DeliveryMode != amqp.Persistent {
+				t.Errorf("delivery mode = %d, want persistent", msg.DeliveryMode)
+			}
+			if got := Attempts(msg.Headers); got != tt.attempt {
+				t.Errorf("attempt = %d, want %d", got, tt.attempt)
+			}
+			if _, ok := msg.Headers[ErrorHeader].(string); !ok {
+				t.Errorf("%s header is missing", ErrorHeader)
+			}
+			if msg.Headers[KeyHeader] != "link.created" {
+				t.Errorf("%s header = %v, want link.created", KeyHeader, msg.Headers[KeyHeader])
+			}
+		})
+	}
+}
+
+func TestBusPublishWithoutConfirms(t *testing.T) {
+	channel := fakeChannel{}
+
+	err := New(nil, channel, channel, "links").Publish(context.Background(), eventbus.Event{Key: "link.created"})
+	if err == nil {
+		t.Fatal("Publish() error = nil, want the channel to be rejected")
+	}
+}
@@ -0,0 +1,46 @@
+package rabbitmq
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/amqpconn"
+)
+
+type amqpConsumer interface {
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (
+		<-chan amqp.Delivery,
+		error,
+	)
+	Cancel(consumer string, noWait bool) error
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	QueueDeclarePassive(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+}
+
+type amqpPublisher interface {
+	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+}
+
+type amqpChannel interface {
+	amqpConsumer
+	amqpPublisher
+}
+
+// amqpConfirmPublisher must be a channel in confirm mode.
+type amqpConfirmPublisher interface {
+	PublishWithDeferredConfirmWithContext(
+		ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing,
+	) (*amqp.DeferredConfirmation, error)
+}
+
+type topologyDeclarer interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+}
+
+// connection declares a topology now and after every reconnection.
+type connection interface {
+	Declare(topology func(ch amqpconn.Declarer) error) error
+}
@@ -0,0 +1,99 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus"
+)
+
+const (
+	// AttemptHeader carries the number of failed attempts to handle a message.
+	AttemptHeader = "x-attempt"
+	// ErrorHeader carries the error of the last failed attempt.
+	ErrorHeader = "x-last-error"
+	// KeyHeader carries the routing key the message was published with, as
+	// retried messages are routed by the name of their queue.
+	KeyHeader = "x-routing-key"
+)
+
+// RetryQueue returns the name of the queue that delays the retries of queue
+// by delay. The delay is part of the name, as the TTL of a declared queue
+// cannot be changed.
+func RetryQueue(queue string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%d", queue, delay.Milliseconds())
+}
+
+// DeadLetterQueue returns the name of the queue of the messages that could
+// not be handled in MaxAttempts attempts.
+func DeadLetterQueue(queue string) string {
+	return queue + ".dead"
+}
+
+// DeclareQueues declares the topic exchange and the queue of sub bound to it,
+// along with its retry and dead-letter queues. The queues are durable so that
+// the events they hold survive a restart of the broker. Retry queues have no
+// consumers: their messages are dead-lettered back to the queue once the TTL
+// of the retry queue expires.
+//
+// There is a retry queue per delay rather than per attempt, named after its
+// TTL. Redeclaring a queue with another TTL fails with PRECONDITION_FAILED,
+// so a changed backoff declares new retry queues instead. The retry queues of
+// the former delays are left to drain and may be deleted once empty.
+func DeclareQueues(ch topologyDeclarer, exchange string, sub eventbus.Subscription) error {
+	if err := ch.ExchangeDeclare(exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("ExchangeDeclare %s: %w", exchange, err)
+	}
+
+	if _, err := ch.QueueDeclare(sub.Queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("QueueDeclare %s: %w", sub.Queue, err)
+	}
+
+	for _, key := range sub.Keys {
+		if err := ch.QueueBind(sub.Queue, key, exchange, false, nil); err != nil {
+			return fmt.Errorf("QueueBind %s: %w", key, err)
+		}
+	}
+
+	declared := map[string]bool{}
+	for attempt := 1; attempt < sub.MaxAttempts; attempt++ {
+		delay := sub.Backoff(attempt)
+		name := RetryQueue(sub.Queue, delay)
+		if declared[name] {
+			continue
+		}
+
+		_, err := ch.QueueDeclare(name, true, false, false, false, amqp.Table{
+			"x-message-ttl":             delay.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": sub.Queue,
+		})
+		if err != nil {
+			return fmt.Errorf("QueueDeclare %s: %w", name, err)
+		}
+		declared[name] = true
+	}
+
+	name := DeadLetterQueue(sub.Queue)
+	if _, err := ch.QueueDeclare(name, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("QueueDeclare %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Attempts returns the number of failed attempts carried by the headers.
+func Attempts(headers amqp.Table) int {
+	switch v := headers[AttemptHeader].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	}
+
+	return 0
+}
@@ -0,0 +1,96 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/eventbus"
+)
+
+// fakeDeclarer records the TTL of the declared queues, zero for the queues
+// without one.
+type fakeDeclarer struct {
+	queues map[string]int64
+	order  []string
+}
+
+func (d *fakeDeclarer) ExchangeDeclare(string, string, bool, bool, bool, bool, amqp.Table) error {
+	return nil
+}
+
+func (d *fakeDeclarer) QueueDeclare(name string, _, _, _, _ bool, args amqp.Table) (amqp.Queue, error) {
+	if _, ok := d.queues[name]; ok {
+		return amqp.Queue{}, fmt.Errorf("queue %s is declared twice", name)
+	}
+
+	ttl, _ := args["x-message-ttl"].(int64)
+	d.queues[name] = ttl
+	d.order = append(d.order, name)
+
+	return amqp.Queue{Name: name}, nil
+}
+
+func (d *fakeDeclarer) QueueBind(string, string, string, bool, amqp.Table) error {
+	return nil
+}
+
+func TestDeclareQueues(t *testing.T) {
+	tests := []struct {
+		name  string
+		sub   eventbus.Subscription
+		retry map[string]int64
+	}{
+		{
+			name: "test_retry_queues_are_named_after_their_ttl",
+			sub:  eventbus.Subscription{Queue: "links", MaxAttempts: 4, RetryDelay: time.Second},
+			retry: map[string]int64{
+				"links.retry.1000": 1000,
+				"links.retry.2000": 2000,
+				"links.retry.4000": 4000,
+			},
+		},
+		{
+			name: "test_changed_delay_declares_new_queues",
+			sub:  eventbus.Subscription{Queue: "links", MaxAttempts: 3, RetryDelay: 1500 * time.Millisecond},
+			retry: map[string]int64{
+				"links.retry.1500": 1500,
+				"links.retry.3000": 3000,
+			},
+		},
+		{
+			name:  "test_same_delay_shares_a_queue",
+			sub:   eventbus.Subscription{Queue: "links", MaxAttempts: 4},
+			retry: map[string]int64{"links.retry.0": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &fakeDeclarer{queues: map[string]int64{}}
+
+			if err := DeclareQueues(d, "links", tt.sub); err != nil {
+				t.Fatalf("DeclareQueues() error = %v", err)
+			}
+
+			// the queue, the retry queues and the dead-letter queue
+			if len(d.queues) != len(tt.retry)+2 {
+				t.Errorf("declared %v, want %d queues", d.order, len(tt.retry)+2)
+			}
+			if _, ok := d.queues[DeadLetterQueue("links")]; !ok {
+				t.Errorf("declared %v, want the dead-letter queue", d.order)
+			}
+			for name, ttl := range tt.retry {
+				got, ok := d.queues[name]
+				if !ok {
+					t.Errorf("declared %v, want %s", d.order, name)
+					continue
+				}
+				if got != ttl {
+					t.Errorf("%s TTL = %d, want %d", name, got, ttl)
+				}
+			}
+		})
+	}
+}
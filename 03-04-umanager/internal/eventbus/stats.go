@@ -0,0 +1,71 @@
+package eventbus
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// Stats counts the events handled by a subscription.
+type Stats struct {
+	inFlight  atomic.Int64
+	processed atomic.Int64
+	failed    atomic.Int64
+	latency   atomic.Int64
+}
+
+// Observe returns handler counting its calls in s.
+func (s *Stats) Observe(handler Handler) Handler {
+	return func(ctx context.Context, e Event) error {
+		start := time.Now()
+		s.inFlight.Add(1)
+		defer s.inFlight.Add(-1)
+
+		err := handler(ctx, e)
+
+		s.processed.Add(1)
+		s.latency.Add(int64(time.Since(start)))
+		if err != nil {
+			s.failed.Add(1)
+		}
+
+		return err
+	}
+}
+
+// Report logs the depth of the queue of sub and the throughput and latency of
+// its workers every StatsInterval until ctx is done.
+func (s *Stats) Report(ctx context.Context, sub Subscription, depth func() (int, error)) {
+	ticker := time.NewTicker(sub.StatsInterval)
+	defer ticker.Stop()
+
+	var lastProcessed, lastLatency int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		processed, latency := s.processed.Load(), s.latency.Load()
+
+		attrs := []any{
+			slog.String("queue", sub.Queue),
+			slog.Int64("in_flight", s.inFlight.Load()),
+			slog.Int64("processed", processed-lastProcessed),
+			slog.Int64("failed_total", s.failed.Load()),
+		}
+		if n := processed - lastProcessed; n > 0 {
+			attrs = append(attrs, slog.Duration("avg_latency", time.Duration((latency-lastLatency)/n)))
+		}
+		if n, err := depth(); err == nil {
+			attrs = append(attrs, slog.Int("queue_depth", n))
+		} else {
+			slog.Warn("queue depth", slog.Any("err", err))
+		}
+
+		slog.Info("subscription stats", attrs...)
+		lastProcessed, lastLatency = processed, latency
+	}
+}
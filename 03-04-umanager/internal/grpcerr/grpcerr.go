@@ -0,0 +1,87 @@
+// Package grpcerr translates errors of the services to gRPC statuses.
+package grpcerr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+)
+
+// Violation describes an invalid field of a request.
+func Violation(field, description string) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{Field: field, Description: description}
+}
+
+// BadRequest returns an InvalidArgument status carrying the field violations
+// as errdetails.BadRequest.
+func BadRequest(violations ...*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(codes.InvalidArgument, "invalid request")
+	if len(violations) == 1 {
+		st = status.Newf(codes.InvalidArgument, "invalid %s: %s", violations[0].Field, violations[0].Description)
+	}
+
+	withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
+
+// InvalidArgument is a shortcut for BadRequest with a single violation.
+func InvalidArgument(field, description string) error {
+	return BadRequest(Violation(field, description))
+}
+
+// FromError converts err to a gRPC status error. Statuses are returned as is,
+// database sentinel errors get their codes and any other error becomes
+// Internal without exposing its message to the client.
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case errors.Is(err, database.ErrConflict):
+		return status.Error(codes.AlreadyExists, "already exists")
+	case errors.Is(err, database.ErrInvalidCursor):
+		return InvalidArgument("page_token", err.Error())
+	case errors.Is(err, database.ErrInvalidSort):
+		return InvalidArgument("sort", err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	slog.Error("internal error", slog.Any("err", err))
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+// UnaryServerInterceptor applies FromError to the errors returned by handlers.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, FromError(err)
+		}
+
+		return resp, nil
+	}
+}
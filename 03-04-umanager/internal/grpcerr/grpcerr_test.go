@@ -0,0 +1,87 @@
+package grpcerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/database"
+)
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{
+			name:     "test_nil",
+			err:      nil,
+			expected: codes.OK,
+		},
+		{
+			name:     "test_status_passed_through",
+			err:      status.Error(codes.PermissionDenied, "permission denied"),
+			expected: codes.PermissionDenied,
+		},
+		{
+			name:     "test_wrapped_not_found",
+			err:      fmt.Errorf("users repository: %w", database.ErrNotFound),
+			expected: codes.NotFound,
+		},
+		{
+			name:     "test_conflict",
+			err:      database.ErrConflict,
+			expected: codes.AlreadyExists,
+		},
+		{
+			name:     "test_invalid_cursor",
+			err:      database.ErrInvalidCursor,
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "test_deadline",
+			err:      context.DeadlineExceeded,
+			expected: codes.DeadlineExceeded,
+		},
+		{
+			name:     "test_unknown_error_is_internal",
+			err:      errors.New("mongo FindOne: connection refused"),
+			expected: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := status.Code(FromError(tt.err))
+			if got != tt.expected {
+				t.Errorf("FromError() code = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBadRequest(t *testing.T) {
+	err := BadRequest(Violation("username", "is required"), Violation("password", "is required"))
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("BadRequest() code = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+
+	var fields []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				fields = append(fields, v.Field)
+			}
+		}
+	}
+	if len(fields) != 2 || fields[0] != "username" || fields[1] != "password" {
+		t.Errorf("BadRequest() field violations = %v", fields)
+	}
+}
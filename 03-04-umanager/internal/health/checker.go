@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check reports an error when a dependency of the service is not usable.
+type Check func(ctx context.Context) error
+
+// Checker runs the checks of the dependencies of a gRPC server periodically
+// and reports the result through the standard grpc.health.v1 service: the
+// server and its services are SERVING while all the checks pass.
+type Checker struct {
+	server   *health.Server
+	services []string
+	checks   map[string]Check
+	interval time.Duration
+}
+
+// New returns a Checker of the given services of server. The services are
+// NOT_SERVING until the first run of the checks.
+func New(server *health.Server, services []string, checks map[string]Check, interval time.Duration) *Checker {
+	c := &Checker{
+		server:   server,
+		services: append([]string{""}, services...),
+		checks:   checks,
+		interval: interval,
+	}
+	c.set(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	return c
+}
+
+// Run checks the dependencies every interval until ctx is done. After that
+// the services are NOT_SERVING, so that clients stop sending new requests.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			c.server.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs the checks once and updates the status of the services.
+func (c *Checker) Check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	for _, name := range names {
+		if err := c.checks[name](ctx); err != nil {
+			slog.Warn("health check failed", slog.String("check", name), slog.Any("err", err))
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	c.set(status)
+}
+
+func (c *Checker) set(status grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,124 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// serverClient calls a health server directly.
+type serverClient struct {
+	grpc_health_v1.HealthClient
+	server *health.Server
+}
+
+func (c serverClient) Check(
+	ctx context.Context, in *grpc_health_v1.HealthCheckRequest, _ ...grpc.CallOption,
+) (*grpc_health_v1.HealthCheckResponse, error) {
+	return c.server.Check(ctx, in)
+}
+
+func TestChecker(t *testing.T) {
+	failing := func(context.Context) error { return errors.New("connection refused") }
+	passing := func(context.Context) error { return nil }
+
+	tests := []struct {
+		name     string
+		checks   map[string]Check
+		expected grpc_health_v1.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:     "test_all_checks_pass",
+			checks:   map[string]Check{"postgres": passing},
+			expected: grpc_health_v1.HealthCheckResponse_SERVING,
+		},
+		{
+			name:     "test_one_check_fails",
+			checks:   map[string]Check{"mongo": passing, "amqp": failing},
+			expected: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := health.NewServer()
+			checker := New(server, []string{"pb.Service"}, tt.checks, time.Second)
+
+			for _, service := range []string{"", "pb.Service"} {
+				resp, err := server.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+				if err != nil {
+					t.Fatalf("Check(%q) error = %v", service, err)
+				}
+				if resp.Status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+					t.Errorf("status of %q before the checks = %v, want NOT_SERVING", service, resp.Status)
+				}
+			}
+
+			checker.Check(context.Background())
+
+			for _, service := range []string{"", "pb.Service"} {
+				resp, err := server.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+				if err != nil {
+					t.Fatalf("Check(%q) error = %v", service, err)
+				}
+				if resp.Status != tt.expected {
+					t.Errorf("status of %q = %v, want %v", service, resp.Status, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestProbesReady(t *testing.T) {
+	serving := health.NewServer()
+	notServing := health.NewServer()
+	notServing.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	tests := []struct {
+		name     string
+		services map[string]grpc_health_v1.HealthClient
+		status   int
+	}{
+		{
+			name: "test_all_serving",
+			services: map[string]grpc_health_v1.HealthClient{
+				"users": serverClient{server: serving},
+				"links": serverClient{server: serving},
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "test_one_not_serving",
+			services: map[string]grpc_health_v1.HealthClient{
+				"users": serverClient{server: serving},
+				"links": serverClient{server: notServing},
+			},
+			status: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewProbes(tt.services, time.Second).Ready(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+
+			var got Status
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if len(got.Services) != len(tt.services) {
+				t.Errorf("services = %v, want %d entries", got.Services, len(tt.services))
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/httputil"
+)
+
+// Status is the body of the probe responses.
+type Status struct {
+	Status   string            `json:"status"`
+	Services map[string]string `json:"services,omitempty"`
+}
+
+// Probes serves the liveness and readiness of the api-gw. The api-gw is ready
+// while all the services it calls report SERVING.
+type Probes struct {
+	services map[string]grpc_health_v1.HealthClient
+	timeout  time.Duration
+}
+
+func NewProbes(services map[string]grpc_health_v1.HealthClient, timeout time.Duration) *Probes {
+	return &Probes{services: services, timeout: timeout}
+}
+
+// Live reports that the process serves HTTP.
+func (p *Probes) Live(w http.ResponseWriter, _ *http.Request) {
+	httputil.MarshalResponse(w, http.StatusOK, Status{Status: "ok"})
+}
+
+// Ready asks the services for their health concurrently.
+func (p *Probes) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), p.timeout)
+	defer cancel()
+
+	res := Status{Status: "ok", Services: make(map[string]string, len(p.services))}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, client := range p.services {
+		wg.Add(1)
+		go func(name string, client grpc_health_v1.HealthClient) {
+			defer wg.Done()
+
+			status := serviceStatus(ctx, client)
+
+			mu.Lock()
+			defer mu.Unlock()
+			res.Services[name] = status.String()
+			if status != grpc_health_v1.HealthCheckResponse_SERVING {
+				res.Status = "unavailable"
+			}
+		}(name, client)
+	}
+	wg.Wait()
+
+	code := http.StatusOK
+	if res.Status != "ok" {
+		code = http.StatusServiceUnavailable
+	}
+
+	httputil.MarshalResponse(w, code, res)
+}
+
+func serviceStatus(ctx context.Context, client grpc_health_v1.HealthClient) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return grpc_health_v1.HealthCheckResponse_UNKNOWN
+	}
+
+	return resp.GetStatus()
+}
@@ -0,0 +1,60 @@
+package linkgrpc
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+)
+
+// Permissions required by the LinkService methods, see auth.UnaryServerInterceptor.
+var Permissions = map[string]string{
+	"/pb.LinkService/CreateLink":      auth.PermissionLinksWrite,
+	"/pb.LinkService/GetLink":         auth.PermissionLinksRead,
+	"/pb.LinkService/GetLinkByUserID": auth.PermissionLinksRead,
+	"/pb.LinkService/UpdateLink":      auth.PermissionLinksWrite,
+	"/pb.LinkService/DeleteLink":      auth.PermissionLinksWrite,
+	"/pb.LinkService/ListLinks":       auth.PermissionLinksManage,
+	"/pb.LinkService/SearchLinks":     auth.PermissionLinksRead,
+	"/pb.LinkService/RefreshLink":     auth.PermissionLinksWrite,
+}
+
+var (
+	errUnauthenticated  = status.Error(codes.Unauthenticated, "caller is not authenticated")
+	errPermissionDenied = status.Error(codes.PermissionDenied, "permission denied")
+	errLinkNotFound     = status.Error(codes.NotFound, "link not found")
+)
+
+// caller returns the identity stored by auth.UnaryServerInterceptor.
+func caller(ctx context.Context) (auth.Identity, error) {
+	id, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return id, errUnauthenticated
+	}
+
+	return id, nil
+}
+
+// findOwned loads the link and checks that the caller may access it. Links of
+// other users are reported as not found, so their IDs are not disclosed.
+func (h Handler) findOwned(ctx context.Context, id primitive.ObjectID) (database.Link, error) {
+	c, err := caller(ctx)
+	if err != nil {
+		return database.Link{}, err
+	}
+
+	l, err := h.linksRepository.FindByID(ctx, id)
+	if err != nil {
+		return l, err
+	}
+
+	if !c.CanAccess(l.UserID, auth.PermissionLinksManage) {
+		return l, errLinkNotFound
+	}
+
+	return l, nil
+}
@@ -2,26 +2,22 @@ package linkgrpc
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
-	amqp "github.com/rabbitmq/amqp091-go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/grpcerr"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/link/models"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
 )
 
-const ContentTypeJSON = "application/json"
-
 var _ pb.LinkServiceServer = (*Handler)(nil)
 
-func New(linksRepository linksRepository, timeout time.Duration, publisher amqpPublisher, queueName string) *Handler {
+func New(linksRepository linksRepository, timeout time.Duration) *Handler {
 	return &Handler{
 		linksRepository: linksRepository,
-		pub:             publisher,
-		queueName:       queueName,
 		timeout:         timeout,
 	}
 }
@@ -29,12 +25,19 @@ func New(linksRepository linksRepository, timeout time.Duration, publisher amqpP
 type Handler struct {
 	pb.UnimplementedLinkServiceServer
 	linksRepository linksRepository
-	pub             amqpPublisher
-	queueName       string
 	timeout         time.Duration
 }
 
 func (h Handler) GetLinkByUserID(ctx context.Context, id *pb.GetLinksByUserId) (*pb.ListLinkResponse, error) {
+	c, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.CanAccess(id.UserId, auth.PermissionLinksManage) {
+		return nil, errPermissionDenied
+	}
+
 	links, err := h.linksRepository.FindByUserID(ctx, id.UserId)
 	if err != nil {
 		return nil, err
@@ -42,16 +45,7 @@ func (h Handler) GetLinkByUserID(ctx context.Context, id *pb.GetLinksByUserId) (
 
 	res := make([]*pb.Link, len(links))
 	for i, l := range links {
-		res[i] = &pb.Link{
-			Id:        l.ID.Hex(),
-			Title:     l.Title,
-			Url:       l.URL,
-			Images:    l.Images,
-			Tags:      l.Tags,
-			UserId:    l.UserID,
-			CreatedAt: l.CreatedAt.String(),
-			UpdatedAt: l.UpdatedAt.String(),
-		}
+		res[i] = linkToPB(l)
 	}
 	return &pb.ListLinkResponse{Links: res}, err
 }
@@ -60,16 +54,37 @@ func (h Handler) CreateLink(ctx context.Context, request *pb.CreateLinkRequest)
 	ctx, cancel := context.WithTimeout(ctx, h.timeout)
 	defer cancel()
 
-	var (
-		id  primitive.ObjectID
-		err error
-	)
+	c, err := caller(ctx)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+
+	var id primitive.ObjectID
 	if request.Id == "" {
 		id = primitive.NewObjectID()
 	} else {
 		id, err = primitive.ObjectIDFromHex(request.Id)
 	}
 
+	if err != nil {
+		return &pb.Empty{}, grpcerr.InvalidArgument("id", "must be an ObjectID")
+	}
+
+	if request.Url == "" {
+		return &pb.Empty{}, grpcerr.InvalidArgument("url", "is required")
+	}
+
+	now := time.Now()
+	event, err := h.event(models.EventLinkCreated, c, database.Link{
+		ID:        id,
+		Title:     request.Title,
+		URL:       request.Url,
+		Images:    request.Images,
+		Tags:      request.Tags,
+		UserID:    c.UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
 	if err != nil {
 		return &pb.Empty{}, err
 	}
@@ -80,24 +95,11 @@ func (h Handler) CreateLink(ctx context.Context, request *pb.CreateLinkRequest)
 		URL:    request.Url,
 		Images: request.Images,
 		Tags:   request.Tags,
-		UserID: request.UserId,
+		UserID: c.UserID,
+		Events: []database.OutboxEvent{event},
 	}
 
-	link, err := h.linksRepository.Create(ctx, req)
-	if err != nil {
-		return &pb.Empty{}, err
-	}
-
-	data, err := json.Marshal(models.Message{ID: link.ID.Hex()})
-	if err != nil {
-		return &pb.Empty{}, err
-	}
-
-	err = h.pub.Publish("", h.queueName, false, false, amqp.Publishing{
-		ContentType: ContentTypeJSON,
-		Body:        data,
-		Timestamp:   time.Now(),
-	})
+	_, err = h.linksRepository.Create(ctx, req)
 
 	return &pb.Empty{}, err
 }
@@ -108,23 +110,14 @@ func (h Handler) GetLink(ctx context.Context, request *pb.GetLinkRequest) (*pb.L
 
 	id, err := primitive.ObjectIDFromHex(request.Id)
 	if err != nil {
-		return nil, err
+		return nil, grpcerr.InvalidArgument("id", "must be an ObjectID")
 	}
-	l, err := h.linksRepository.FindByID(ctx, id)
+	l, err := h.findOwned(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.Link{
-		Id:        l.ID.Hex(),
-		Title:     l.Title,
-		Url:       l.URL,
-		Images:    l.Images,
-		Tags:      l.Tags,
-		UserId:    l.UserID,
-		CreatedAt: l.CreatedAt.String(),
-		UpdatedAt: l.UpdatedAt.String(),
-	}, nil
+	return linkToPB(l), nil
 }
 
 func (h Handler) UpdateLink(ctx context.Context, request *pb.UpdateLinkRequest) (*pb.Empty, error) {
@@ -132,18 +125,58 @@ func (h Handler) UpdateLink(ctx context.Context, request *pb.UpdateLinkRequest)
 	defer cancel()
 
 	id, err := primitive.ObjectIDFromHex(request.Id)
+	if err != nil {
+		return nil, grpcerr.InvalidArgument("id", "must be an ObjectID")
+	}
+
+	l, err := h.findOwned(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := caller(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now()
+	updated := database.Link{
+		ID:        id,
+		Title:     request.Title,
+		URL:       request.Url,
+		Images:    request.Images,
+		Tags:      request.Tags,
+		UserID:    l.UserID,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: now,
+	}
+	event, err := h.event(models.EventLinkUpdated, c, updated)
+	if err != nil {
+		return nil, err
+	}
 	req := database.UpdateLinkReq{
 		ID:     id,
 		Title:  request.Title,
 		URL:    request.Url,
 		Images: request.Images,
 		Tags:   request.Tags,
-		UserID: request.UserId,
+		UserID: l.UserID,
+		Events: []database.OutboxEvent{event},
+	}
+
+	// the page of a new URL is checked anew, the edits of the rest keep the
+	// enrichment of the link
+	if request.Url != l.URL {
+		refresh, err := h.event(models.EventLinkRefreshRequested, c, updated)
+		if err != nil {
+			return nil, err
+		}
+		req.Events = append(req.Events, refresh)
+
+		e := l.Enrichment.Requeued(now)
+		req.Enrichment = &e
 	}
+
 	_, err = h.linksRepository.Update(ctx, req)
 	return &pb.Empty{}, err
 }
@@ -153,34 +186,188 @@ func (h Handler) DeleteLink(ctx context.Context, request *pb.DeleteLinkRequest)
 	defer cancel()
 
 	id, err := primitive.ObjectIDFromHex(request.Id)
+	if err != nil {
+		return nil, grpcerr.InvalidArgument("id", "must be an ObjectID")
+	}
+
+	l, err := h.findOwned(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := h.event(models.EventLinkDeleted, c, l)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Empty{}, h.linksRepository.Delete(ctx, id, event)
+}
+
+// RefreshLink makes the link pending and asks the link updater to scrape it
+// again.
+func (h Handler) RefreshLink(ctx context.Context, request *pb.RefreshLinkRequest) (*pb.Empty, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	id, err := primitive.ObjectIDFromHex(request.Id)
+	if err != nil {
+		return nil, grpcerr.InvalidArgument("id", "must be an ObjectID")
+	}
+
+	l, err := h.findOwned(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := caller(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.Empty{}, h.linksRepository.Delete(ctx, id)
+	event, err := h.event(models.EventLinkRefreshRequested, c, l)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Empty{}, h.linksRepository.SetEnrichment(ctx, id, l.Enrichment.Requeued(time.Now()), event)
+}
+
+// event returns the outbox record of a change of l made by the caller.
+func (h Handler) event(typ string, c auth.Identity, l database.Link) (database.OutboxEvent, error) {
+	return models.NewEvent(typ, models.Actor{UserID: c.UserID}, l).OutboxEvent()
 }
 
-func (h Handler) ListLinks(ctx context.Context, request *pb.Empty) (*pb.ListLinkResponse, error) {
+func (h Handler) ListLinks(ctx context.Context, request *pb.ListLinksRequest) (*pb.ListLinkResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, h.timeout)
 	defer cancel()
 
-	links, err := h.linksRepository.FindAll(ctx)
+	page, err := database.ParsePage(request.PageSize, request.PageToken, request.Sort)
 	if err != nil {
 		return &pb.ListLinkResponse{}, err
 	}
 
+	return h.findPage(ctx, database.FindLinkCriteria{}, page)
+}
+
+// SearchLinks finds links by tags, text and date ranges. Callers without the
+// links:manage permission may only search their own links.
+func (h Handler) SearchLinks(ctx context.Context, request *pb.SearchLinksRequest) (*pb.ListLinkResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	c, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.CanAccess(request.UserId, auth.PermissionLinksManage) {
+		return nil, errPermissionDenied
+	}
+
+	page, err := database.ParsePage(request.PageSize, request.PageToken, request.Sort)
+	if err != nil {
+		return &pb.ListLinkResponse{}, err
+	}
+
+	criteria := database.FindLinkCriteria{
+		Tags:         request.Tags,
+		TagsMatchAll: request.MatchAllTags,
+	}
+	if request.UserId != "" {
+		criteria.UserID = &request.UserId
+	}
+	if request.Query != "" {
+		criteria.Text = &request.Query
+	}
+	criteria.Broken = request.Broken
+
+	for _, r := range []struct {
+		field string
+		value string
+		dst   **time.Time
+	}{
+		{"created_after", request.CreatedAfter, &criteria.CreatedAfter},
+		{"created_before", request.CreatedBefore, &criteria.CreatedBefore},
+		{"updated_after", request.UpdatedAfter, &criteria.UpdatedAfter},
+		{"updated_before", request.UpdatedBefore, &criteria.UpdatedBefore},
+	} {
+		if r.value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, r.value)
+		if err != nil {
+			return &pb.ListLinkResponse{}, grpcerr.InvalidArgument(r.field, "must be an RFC 3339 date-time")
+		}
+		*r.dst = &t
+	}
+
+	return h.findPage(ctx, criteria, page)
+}
+
+// findPage returns a page of links matching the criteria.
+func (h Handler) findPage(
+	ctx context.Context, criteria database.FindLinkCriteria, page database.Page,
+) (*pb.ListLinkResponse, error) {
+	// one extra link tells whether there is a next page
+	limit := page.Limit + 1
+	criteria.Limit = &limit
+	criteria.After = page.After
+	criteria.SortDesc = page.SortDesc
+
+	links, err := h.linksRepository.FindByCriteria(ctx, criteria)
+	if err != nil {
+		return &pb.ListLinkResponse{}, err
+	}
+
+	var next string
+	if int64(len(links)) > page.Limit {
+		links = links[:page.Limit]
+		last := links[len(links)-1]
+		next = database.EncodeCursor(database.Cursor{CreatedAt: last.CreatedAt, ID: last.ID.Hex()})
+	}
+
 	res := make([]*pb.Link, len(links))
 	for i, l := range links {
-		res[i] = &pb.Link{
-			Id:        l.ID.Hex(),
-			Title:     l.Title,
-			Url:       l.URL,
-			Images:    l.Images,
-			Tags:      l.Tags,
-			UserId:    l.UserID,
-			CreatedAt: l.CreatedAt.String(),
-			UpdatedAt: l.UpdatedAt.String(),
-		}
+		res[i] = linkToPB(l)
+	}
+	return &pb.ListLinkResponse{Links: res, NextPageToken: next}, nil
+}
+
+func linkToPB(l database.Link) *pb.Link {
+	e := l.Enrichment
+	if e.State == "" {
+		e.State = database.EnrichmentPending
+	}
+
+	var lastAttemptAt string
+	if e.LastAttemptAt != nil {
+		lastAttemptAt = e.LastAttemptAt.UTC().Format(time.RFC3339)
+	}
+
+	return &pb.Link{
+		Id:        l.ID.Hex(),
+		Title:     l.Title,
+		Url:       l.URL,
+		Images:    l.Images,
+		Tags:      l.Tags,
+		UserId:    l.UserID,
+		CreatedAt: l.CreatedAt.String(),
+		UpdatedAt: l.UpdatedAt.String(),
+		Enrichment: &pb.Enrichment{
+			State:               string(e.State),
+			Attempts:            int32(e.Attempts),
+			LastAttemptAt:       lastAttemptAt,
+			HttpStatus:          int32(e.HTTPStatus),
+			LastError:           e.LastError,
+			RedirectUrl:         e.RedirectURL,
+			ConsecutiveFailures: int32(e.ConsecutiveFailures),
+			Broken:              e.Broken,
+		},
 	}
-	return &pb.ListLinkResponse{Links: res}, err
 }
@@ -0,0 +1,114 @@
+package linkgrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/link/models"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/pb"
+)
+
+// fakeLinksRepository holds a single link and records its updates. The
+// methods the tests do not reach are left to the embedded interface.
+type fakeLinksRepository struct {
+	linksRepository
+	link    database.Link
+	updated []database.UpdateLinkReq
+}
+
+func (r *fakeLinksRepository) FindByID(_ context.Context, id primitive.ObjectID) (database.Link, error) {
+	if id != r.link.ID {
+		return database.Link{}, database.ErrNotFound
+	}
+	return r.link, nil
+}
+
+func (r *fakeLinksRepository) Update(_ context.Context, req database.UpdateLinkReq) (database.Link, error) {
+	r.updated = append(r.updated, req)
+	return r.link, nil
+}
+
+func TestHandlerUpdateLinkEnrichment(t *testing.T) {
+	checked := time.Now().Add(-time.Hour)
+	link := database.Link{
+		ID:     primitive.NewObjectID(),
+		Title:  "Example",
+		URL:    "https://example.com",
+		UserID: "user-1",
+		Enrichment: database.Enrichment{
+			State:         database.EnrichmentOK,
+			Attempts:      1,
+			LastAttemptAt: &checked,
+			HTTPStatus:    200,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		request    *pb.UpdateLinkRequest
+		wantEvents []string
+		requeued   bool
+	}{
+		{
+			name:       "test_edit_keeps_enrichment",
+			request:    &pb.UpdateLinkRequest{Id: link.ID.Hex(), Title: "Renamed", Url: link.URL},
+			wantEvents: []string{models.EventLinkUpdated},
+		},
+		{
+			name:       "test_new_url_is_requeued",
+			request:    &pb.UpdateLinkRequest{Id: link.ID.Hex(), Title: link.Title, Url: "https://example.org"},
+			wantEvents: []string{models.EventLinkUpdated, models.EventLinkRefreshRequested},
+			requeued:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeLinksRepository{link: link}
+			ctx := auth.WithIdentity(context.Background(), auth.Identity{UserID: link.UserID})
+
+			if _, err := New(repo, time.Second).UpdateLink(ctx, tt.request); err != nil {
+				t.Fatalf("UpdateLink() error = %v", err)
+			}
+
+			if len(repo.updated) != 1 {
+				t.Fatalf("updated %d times, want once", len(repo.updated))
+			}
+			req := repo.updated[0]
+
+			var events []string
+			for _, e := range req.Events {
+				events = append(events, e.RoutingKey)
+			}
+			if len(events) != len(tt.wantEvents) {
+				t.Fatalf("events = %v, want %v", events, tt.wantEvents)
+			}
+			for i := range events {
+				if events[i] != tt.wantEvents[i] {
+					t.Errorf("events = %v, want %v", events, tt.wantEvents)
+				}
+			}
+
+			if !tt.requeued {
+				if req.Enrichment != nil {
+					t.Errorf("enrichment = %+v, want the stored one kept", *req.Enrichment)
+				}
+				return
+			}
+
+			if req.Enrichment == nil {
+				t.Fatal("enrichment = nil, want the link queued for a check")
+			}
+			if req.Enrichment.State != database.EnrichmentPending || req.Enrichment.QueuedAt == nil {
+				t.Errorf("enrichment = %+v, want pending", *req.Enrichment)
+			}
+			if req.Enrichment.Attempts != 0 {
+				t.Errorf("attempts = %d, want 0", req.Enrichment.Attempts)
+			}
+		})
+	}
+}
@@ -1,5 +1,96 @@
 package models
 
-type Message struct {
-	ID string `json:"id"`
-}
\ No newline at end of file
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+)
+
+const ContentTypeJSON = "application/json"
+
+// EventVersion is the version of the Event payload. It changes when fields
+// are removed or change their meaning.
+const EventVersion = 1
+
+// Event types. They are the keys of the events on the event bus.
+const (
+	EventLinkCreated  = "link.created"
+	EventLinkUpdated  = "link.updated"
+	EventLinkDeleted  = "link.deleted"
+	EventLinkEnriched = "link.enriched"
+	// EventLinkRefreshRequested asks for the link to be scraped again.
+	EventLinkRefreshRequested = "link.refresh_requested"
+)
+
+// Event is a link lifecycle event. It carries the snapshot of the link after
+// the change, or before it for link.deleted.
+type Event struct {
+	EventID    string    `json:"event_id"`
+	Type       string    `json:"type"`
+	Version    int       `json:"version"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Actor      Actor     `json:"actor"`
+	Link       Link      `json:"link"`
+}
+
+// Actor is who made the change: a user or, for automatic changes, a service.
+type Actor struct {
+	UserID  string `json:"user_id,omitempty"`
+	Service string `json:"service,omitempty"`
+}
+
+type Link struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	Images    []string  `json:"images"`
+	Tags      []string  `json:"tags"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewEvent(typ string, actor Actor, l database.Link) Event {
+	return Event{
+		EventID:    primitive.NewObjectID().Hex(),
+		Type:       typ,
+		Version:    EventVersion,
+		OccurredAt: time.Now().UTC(),
+		Actor:      actor,
+		Link: Link{
+			ID:        l.ID.Hex(),
+			Title:     l.Title,
+			URL:       l.URL,
+			Images:    l.Images,
+			Tags:      l.Tags,
+			UserID:    l.UserID,
+			CreatedAt: l.CreatedAt,
+			UpdatedAt: l.UpdatedAt,
+		},
+	}
+}
+
+// OutboxEvent returns the outbox record publishing e.
+func (e Event) OutboxEvent() (database.OutboxEvent, error) {
+	id, err := primitive.ObjectIDFromHex(e.EventID)
+	if err != nil {
+		return database.OutboxEvent{}, fmt.Errorf("event id: %w", err)
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return database.OutboxEvent{}, fmt.Errorf("json Marshal: %w", err)
+	}
+
+	return database.OutboxEvent{
+		ID:          id,
+		RoutingKey:  e.Type,
+		ContentType: ContentTypeJSON,
+		Body:        body,
+		CreatedAt:   e.OccurredAt,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/database"
+)
+
+func TestEventOutboxEvent(t *testing.T) {
+	link := database.Link{
+		ID:        primitive.NewObjectID(),
+		Title:     "Go",
+		URL:       "https://go.dev",
+		Tags:      []string{"go"},
+		UserID:    "8f5b1f3e-7c4a-4d2b-9a61-2f0c8e4b7d15",
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	for _, typ := range []string{EventLinkCreated, EventLinkUpdated, EventLinkDeleted, EventLinkEnriched} {
+		t.Run(typ, func(t *testing.T) {
+			e := NewEvent(typ, Actor{UserID: link.UserID}, link)
+
+			got, err := e.OutboxEvent()
+			if err != nil {
+				t.Fatalf("OutboxEvent() error = %v", err)
+			}
+			if got.RoutingKey != typ {
+				t.Errorf("routing key = %s, want %s", got.RoutingKey, typ)
+			}
+			if got.ID.Hex() != e.EventID {
+				t.Errorf("outbox id = %s, want the event id %s", got.ID.Hex(), e.EventID)
+			}
+
+			var decoded Event
+			if err := json.Unmarshal(got.Body, &decoded); err != nil {
+				t.Fatalf("json Unmarshal: %v", err)
+			}
+			if decoded.Version != EventVersion || decoded.Link.ID != link.ID.Hex() || decoded.Actor.UserID != link.UserID {
+				t.Errorf("decoded event = %+v", decoded)
+			}
+		})
+	}
+}
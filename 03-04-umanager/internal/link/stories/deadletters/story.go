@@ -0,0 +1,135 @@
+package deadletters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus/rabbitmq"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/link/models"
+)
+
+type amqpChannel interface {
+	Get(queue string, autoAck bool) (amqp.Delivery, bool, error)
+	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+}
+
+// Message is a link message that could not be processed.
+type Message struct {
+	LinkID   string `json:"link_id,omitempty"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error,omitempty"`
+	Body     string `json:"body"`
+}
+
+func New(channel amqpChannel, queueName string) *Story {
+	return &Story{
+		channel:   channel,
+		queueName: queueName,
+	}
+}
+
+// Story inspects the dead-letter queue of the link messages and replays them.
+type Story struct {
+	channel   amqpChannel
+	queueName string
+}
+
+// List returns up to limit dead-lettered messages and leaves them in the queue.
+func (s *Story) List(limit int) ([]Message, error) {
+	deliveries, err := s.get(limit)
+
+	res := make([]Message, 0, len(deliveries))
+	for _, d := range deliveries {
+		res = append(res, message(d))
+		if err := d.Nack(false, true); err != nil {
+			return nil, fmt.Errorf("amqp Nack: %w", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Replay moves up to limit dead-lettered messages back to the queue of the
+// link updater with their attempts reset, and returns them.
+func (s *Story) Replay(limit int) ([]Message, error) {
+	deliveries, err := s.get(limit)
+
+	res := make([]Message, 0, len(deliveries))
+	for i, d := range deliveries {
+		headers := amqp.Table{}
+		for k, v := range d.Headers {
+			headers[k] = v
+		}
+		delete(headers, rabbitmq.AttemptHeader)
+		delete(headers, rabbitmq.ErrorHeader)
+
+		err := s.channel.Publish("", s.queueName, false, false, amqp.Publishing{
+			Headers:      headers,
+			MessageId:    d.MessageId,
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Body:         d.Body,
+			Timestamp:    d.Timestamp,
+		})
+		if err != nil {
+			requeue(deliveries[i:])
+			return res, fmt.Errorf("amqp Publish: %w", err)
+		}
+
+		if err := d.Ack(false); err != nil {
+			requeue(deliveries[i+1:])
+			return res, fmt.Errorf("amqp Ack: %w", err)
+		}
+
+		res = append(res, message(d))
+	}
+
+	return res, err
+}
+
+// get takes up to limit messages from the dead-letter queue without acking
+// them. The messages got before an error are returned along with it.
+func (s *Story) get(limit int) ([]amqp.Delivery, error) {
+	var res []amqp.Delivery
+	for len(res) < limit {
+		d, ok, err := s.channel.Get(rabbitmq.DeadLetterQueue(s.queueName), false)
+		if err != nil {
+			return res, fmt.Errorf("amqp Get: %w", err)
+		}
+		if !ok {
+			break
+		}
+
+		res = append(res, d)
+	}
+
+	return res, nil
+}
+
+func message(d amqp.Delivery) Message {
+	res := Message{
+		Attempts: rabbitmq.Attempts(d.Headers),
+		Body:     string(d.Body),
+	}
+	if v, ok := d.Headers[rabbitmq.ErrorHeader].(string); ok {
+		res.Error = v
+	}
+
+	var e models.Event
+	if err := json.Unmarshal(d.Body, &e); err == nil {
+		res.LinkID = e.Link.ID
+	}
+
+	return res
+}
+
+func requeue(deliveries []amqp.Delivery) {
+	for _, d := range deliveries {
+		_ = d.Nack(false, true)
+	}
+}
@@ -0,0 +1,182 @@
+package deadletters
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/eventbus/rabbitmq"
+)
+
+type fakeChannel struct {
+	queue     string
+	messages  []amqp.Delivery
+	published []amqp.Publishing
+	// failAt is the number of the publish that fails, starting at 1
+	failAt int
+}
+
+func (c *fakeChannel) Get(queue string, _ bool) (amqp.Delivery, bool, error) {
+	if queue != c.queue {
+		return amqp.Delivery{}, false, fmt.Errorf("unexpected queue %s", queue)
+	}
+	if len(c.messages) == 0 {
+		return amqp.Delivery{}, false, nil
+	}
+
+	d := c.messages[0]
+	c.messages = c.messages[1:]
+	return d, true, nil
+}
+
+func (c *fakeChannel) Publish(_, key string, _, _ bool, msg amqp.Publishing) error {
+	if len(c.published)+1 == c.failAt {
+		return errors.New("amqp channel is closed")
+	}
+	if key != "links" {
+		return fmt.Errorf("unexpected key %s", key)
+	}
+
+	c.published = append(c.published, msg)
+	return nil
+}
+
+type fakeAcknowledger struct {
+	acked    []uint64
+	requeued []uint64
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, _ bool) error {
+	a.acked = append(a.acked, tag)
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, _ bool, requeue bool) error {
+	if requeue {
+		a.requeued = append(a.requeued, tag)
+	}
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func deadLetters(ack amqp.Acknowledger, n int) []amqp.Delivery {
+	res := make([]amqp.Delivery, 0, n)
+	for i := 1; i <= n; i++ {
+		res = append(res, amqp.Delivery{
+			Acknowledger: ack,
+			DeliveryTag:  uint64(i),
+			Headers: amqp.Table{
+				rabbitmq.AttemptHeader: int32(5),
+				rabbitmq.ErrorHeader:   "mongo FindOne: connection refused",
+				rabbitmq.KeyHeader:     "link.created",
+			},
+			MessageId:   fmt.Sprintf("event-%d", i),
+			ContentType: "application/json",
+			Body:        []byte(fmt.Sprintf(`{"type":"link.created","link":{"id":"link-%d"}}`, i)),
+		})
+	}
+
+	return res
+}
+
+func TestStoryList(t *testing.T) {
+	ack := &fakeAcknowledger{}
+	channel := &fakeChannel{queue: rabbitmq.DeadLetterQueue("links"), messages: deadLetters(ack, 3)}
+
+	got, err := New(channel, "links").List(2)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("List() returned %d messages, want 2", len(got))
+	}
+	want := Message{
+		LinkID:   "link-1",
+		Attempts: 5,
+		Error:    "mongo FindOne: connection refused",
+		Body:     `{"type":"link.created","link":{"id":"link-1"}}`,
+	}
+	if got[0] != want {
+		t.Errorf("List()[0] = %+v, want %+v", got[0], want)
+	}
+	if len(ack.requeued) != 2 || len(ack.acked) != 0 {
+		t.Errorf("requeued = %v, acked = %v, want the listed messages to stay in the queue", ack.requeued, ack.acked)
+	}
+	if len(channel.published) != 0 {
+		t.Errorf("published %d messages, want nothing", len(channel.published))
+	}
+}
+
+func TestStoryReplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		failAt   int
+		replayed int
+		acked    []uint64
+		requeued []uint64
+	}{
+		{
+			name:     "test_messages_are_replayed",
+			replayed: 2,
+			acked:    []uint64{1, 2},
+		},
+		{
+			name:     "test_publish_error_requeues_the_rest",
+			failAt:   2,
+			replayed: 1,
+			acked:    []uint64{1},
+			requeued: []uint64{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ack := &fakeAcknowledger{}
+			channel := &fakeChannel{
+				queue:    rabbitmq.DeadLetterQueue("links"),
+				messages: deadLetters(ack, 3),
+				failAt:   tt.failAt,
+			}
+
+			got, err := New(channel, "links").Replay(2)
+			if tt.failAt != 0 && err == nil {
+				t.Error("Replay() error = nil")
+			}
+			if tt.failAt == 0 && err != nil {
+				t.Errorf("Replay() error = %v", err)
+			}
+
+			if len(got) != tt.replayed || len(channel.published) != tt.replayed {
+				t.Fatalf("replayed %d, published %d, want %d", len(got), len(channel.published), tt.replayed)
+			}
+			if fmt.Sprint(ack.acked) != fmt.Sprint(tt.acked) {
+				t.Errorf("acked = %v, want %v", ack.acked, tt.acked)
+			}
+			if fmt.Sprint(ack.requeued) != fmt.Sprint(tt.requeued) {
+				t.Errorf("requeued = %v, want %v", ack.requeued, tt.requeued)
+			}
+
+			msg := channel.published[0]
+			if _, ok := msg.Headers[rabbitmq.AttemptHeader]; ok {
+				t.Errorf("%s header is kept", rabbitmq.AttemptHeader)
+			}
+			if _, ok := msg.Headers[rabbitmq.ErrorHeader]; ok {
+				t.Errorf("%s header is kept", rabbitmq.ErrorHeader)
+			}
+			if msg.Headers[rabbitmq.KeyHeader] != "link.created" {
+				t.Errorf("%s header = %v, want link.created", rabbitmq.KeyHeader, msg.Headers[rabbitmq.KeyHeader])
+			}
+			if msg.DeliveryMode != amqp.Persistent {
+				t.Errorf("delivery mode = %d, want persistent", msg.DeliveryMode)
+			}
+			if msg.MessageId != "event-1" {
+				t.Errorf("message id = %q, want event-1", msg.MessageId)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package linkscheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/link/models"
+)
+
+const serviceName = "linkscheduler"
+
+type repository interface {
+	FindQueuedBefore(ctx context.Context, before time.Time, limit int64) ([]database.Link, error)
+	Requeue(
+		ctx context.Context, id primitive.ObjectID, prev database.Enrichment, t time.Time, events ...database.OutboxEvent,
+	) error
+}
+
+type Config struct {
+	// Interval is how often a batch of links is queued. Zero disables the
+	// checks.
+	Interval time.Duration
+	// Jitter, from 0 to 1, is the part of Interval the wait for the next
+	// batch is changed by at random, so that the instances of the service
+	// spread their queries over time. It does not keep them from picking
+	// the same links: Schedule skips the links queued meanwhile for that.
+	Jitter float64
+	// MaxAge is how long ago a link is queued last before it is checked
+	// again.
+	MaxAge    time.Duration
+	BatchSize int64
+}
+
+func New(repository repository, cfg Config) *Story {
+	return &Story{
+		repository: repository,
+		cfg:        cfg,
+	}
+}
+
+// Story queues the links checked too long ago for the link updater, so that
+// their metadata is refreshed and the broken ones are found.
+type Story struct {
+	repository repository
+	cfg        Config
+}
+
+// Run queues a batch of links every Interval until ctx is done.
+func (s *Story) Run(ctx context.Context) error {
+	if s.cfg.Interval <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(s.wait())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		n, err := s.Schedule(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("schedule links", slog.Any("err", err))
+		}
+		if n > 0 {
+			slog.Info("links are queued for a check", slog.Int("count", n))
+		}
+
+		timer.Reset(s.wait())
+	}
+}
+
+// Schedule queues up to BatchSize links last queued before MaxAge ago,
+// writing the events for the link updater to the outbox, and returns the
+// number of the queued links. A link queued or checked since it was found,
+// by another instance for one, is skipped.
+func (s *Story) Schedule(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	links, err := s.repository.FindQueuedBefore(ctx, now.Add(-s.cfg.MaxAge), s.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("links FindQueuedBefore: %w", err)
+	}
+
+	queued := 0
+	for _, l := range links {
+		event, err := models.NewEvent(models.EventLinkRefreshRequested, models.Actor{Service: serviceName}, l).OutboxEvent()
+		if err != nil {
+			return queued, err
+		}
+
+		err = s.repository.Requeue(ctx, l.ID, l.Enrichment, now, event)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return queued, fmt.Errorf("links Requeue %s: %w", l.ID.Hex(), err)
+		}
+		queued++
+	}
+
+	return queued, nil
+}
+
+// wait returns Interval changed by up to Jitter of it.
+func (s *Story) wait() time.Duration {
+	jitter := (rand.Float64()*2 - 1) * s.cfg.Jitter * float64(s.cfg.Interval)
+
+	return s.cfg.Interval + time.Duration(jitter)
+}
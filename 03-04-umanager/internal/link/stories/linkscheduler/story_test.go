@@ -0,0 +1,121 @@
+package linkscheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/link/models"
+)
+
+// fakeRepository holds the enrichment stored for every link, which may have
+// changed since the links were found.
+type fakeRepository struct {
+	links  []database.Link
+	stored map[primitive.ObjectID]database.Enrichment
+	before time.Time
+	queued map[primitive.ObjectID]time.Time
+	events []database.OutboxEvent
+}
+
+func (r *fakeRepository) FindQueuedBefore(_ context.Context, before time.Time, limit int64) ([]database.Link, error) {
+	r.before = before
+	if int64(len(r.links)) > limit {
+		return r.links[:limit], nil
+	}
+	return r.links, nil
+}
+
+func (r *fakeRepository) Requeue(
+	_ context.Context, id primitive.ObjectID, prev database.Enrichment, t time.Time, events ...database.OutboxEvent,
+) error {
+	stored, ok := r.stored[id]
+	if !ok || stored.State != prev.State || !equalTime(stored.QueuedAt, prev.QueuedAt) {
+		return database.ErrNotFound
+	}
+	r.stored[id] = stored.Requeued(t)
+	r.queued[id] = t
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestStorySchedule(t *testing.T) {
+	checked := time.Now().Add(-48 * time.Hour)
+	broken := database.Link{ID: primitive.NewObjectID(), Enrichment: database.Enrichment{
+		State:               database.EnrichmentFailed,
+		Attempts:            5,
+		QueuedAt:            &checked,
+		HTTPStatus:          404,
+		ConsecutiveFailures: 3,
+		Broken:              true,
+	}}
+	deleted := database.Link{ID: primitive.NewObjectID()}
+	legacy := database.Link{ID: primitive.NewObjectID()}
+	// another instance queued the link after it was found
+	requeued := database.Link{ID: primitive.NewObjectID(), Enrichment: database.Enrichment{
+		State:    database.EnrichmentOK,
+		QueuedAt: &checked,
+	}}
+	now := time.Now()
+
+	repo := &fakeRepository{
+		links: []database.Link{broken, deleted, legacy, requeued},
+		stored: map[primitive.ObjectID]database.Enrichment{
+			broken.ID:   broken.Enrichment,
+			legacy.ID:   legacy.Enrichment,
+			requeued.ID: requeued.Enrichment.Requeued(now),
+		},
+		queued: map[primitive.ObjectID]time.Time{},
+	}
+	s := New(repo, Config{MaxAge: 24 * time.Hour, BatchSize: 4})
+
+	n, err := s.Schedule(context.Background())
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Schedule() = %d, want 2 links queued", n)
+	}
+	if age := time.Since(repo.before); age < 24*time.Hour || age > 25*time.Hour {
+		t.Errorf("links queued before %s, want a day ago", repo.before)
+	}
+
+	if at, ok := repo.queued[broken.ID]; !ok || !at.After(checked) {
+		t.Errorf("broken link queued at %s, want now", at)
+	}
+	if _, ok := repo.queued[legacy.ID]; !ok {
+		t.Error("link without enrichment is not queued")
+	}
+	if _, ok := repo.queued[requeued.ID]; ok {
+		t.Error("link queued meanwhile is queued again")
+	}
+
+	if len(repo.events) != 2 {
+		t.Fatalf("events = %d, want 2", len(repo.events))
+	}
+	for _, e := range repo.events {
+		if e.RoutingKey != models.EventLinkRefreshRequested {
+			t.Errorf("event key = %s, want %s", e.RoutingKey, models.EventLinkRefreshRequested)
+		}
+	}
+}
+
+func TestStoryWait(t *testing.T) {
+	s := New(nil, Config{Interval: time.Minute, Jitter: 0.2})
+
+	for i := 0; i < 100; i++ {
+		if d := s.wait(); d < 48*time.Second || d > 72*time.Second {
+			t.Fatalf("wait() = %s, want within 20%% of a minute", d)
+		}
+	}
+}
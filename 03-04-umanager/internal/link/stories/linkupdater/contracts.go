@@ -2,21 +2,27 @@ package linkupdater
 
 import (
 	"context"
+	"time"
 
-	amqp "github.com/rabbitmq/amqp091-go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/scrape"
 )
 
 type repository interface {
 	FindByID(ctx context.Context, id primitive.ObjectID) (database.Link, error)
 	Update(ctx context.Context, req database.UpdateLinkReq) (database.Link, error)
+	SetEnrichment(ctx context.Context, id primitive.ObjectID, e database.Enrichment, events ...database.OutboxEvent) error
+}
+
+type scraper interface {
+	Fetch(ctx context.Context, url string) (*scrape.Page, error)
+	CrawlDelay(ctx context.Context, url string) (time.Duration, error)
 }
 
-type amqpConsumer interface {
-	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (
-		<-chan amqp.Delivery,
-		error,
-	)
+type subscriber interface {
+	Subscribe(ctx context.Context, sub eventbus.Subscription, handler eventbus.Handler) error
 }
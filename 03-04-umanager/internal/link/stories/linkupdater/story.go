@@ -4,92 +4,256 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
 
-	"github.com/rabbitmq/amqp091-go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/link/models"
 
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/hostlimit"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/scrape"
 )
 
-func New(repository repository, consumer amqpConsumer, queueName string) *Story {
+const serviceName = "linkupdater"
+
+// BindingKeys are the events the link updater consumes: links are scraped
+// when they are created and when they are asked to be checked again, by a
+// user, by a change of their URL or by the scheduler.
+var BindingKeys = []string{models.EventLinkCreated, models.EventLinkRefreshRequested}
+
+// Config configures the consumption of the link events.
+type Config struct {
+	// Subscription is the queue of the link updater. Its Keys are set to
+	// BindingKeys.
+	Subscription eventbus.Subscription
+	// HostRate is the number of pages fetched per second from a host, with
+	// bursts of HostBurst pages. Zero disables the limit.
+	HostRate  float64
+	HostBurst int
+	// HostConcurrency is the number of pages fetched at once from a host.
+	// Zero disables the limit.
+	HostConcurrency int
+	// BrokenAfter is the number of the checks of a link failed in a row
+	// after which it is broken. Zero disables the broken links.
+	BrokenAfter int
+}
+
+func New(repository repository, bus subscriber, scraper scraper, cfg Config) *Story {
+	cfg.Subscription.Keys = BindingKeys
+
 	return &Story{
 		repository: repository,
-		consumer:   consumer,
-		queueName:  queueName,
+		bus:        bus,
+		scraper:    scraper,
+		cfg:        cfg,
+		hosts:      hostlimit.New(cfg.HostRate, cfg.HostBurst, cfg.HostConcurrency),
 	}
 }
 
 type Story struct {
 	repository repository
-	consumer   amqpConsumer
-	queueName  string
+	bus        subscriber
+	scraper    scraper
+	cfg        Config
+	hosts      *hostlimit.Limiter
 }
 
-func (s *Story) Run(ctx context.Context) error {
-	ch, err := s.consumer.Consume(s.queueName, "", true, false, false, false, nil)
-	if err != nil {
-		return err
-	}
+// Subscription returns the subscription of the link updater to declare.
+func (s *Story) Subscription() eventbus.Subscription {
+	return s.cfg.Subscription
+}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case m, ok := <-ch:
-			if !ok {
-				return errors.New("rabbitmq queue is closed")
-			}
-			err := s.processMsg(ctx, m)
-			if err != nil {
-				slog.Error("process message error", slog.Any("err", err))
-			}
-		}
-	}
+// Run scrapes the links of the events until ctx is done. The failed events
+// are retried by the bus.
+func (s *Story) Run(ctx context.Context) error {
+	return s.bus.Subscribe(ctx, s.cfg.Subscription, s.handle)
 }
 
-func (s *Story) processMsg(ctx context.Context, msg amqp091.Delivery) error {
-	var m models.Message
-	err := json.Unmarshal(msg.Body, &m)
+func (s *Story) handle(ctx context.Context, msg eventbus.Event) error {
+	var e models.Event
+	err := json.Unmarshal(msg.Body, &e)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: json Unmarshal: %w", eventbus.ErrPermanent, err)
 	}
 
-	id, err := primitive.ObjectIDFromHex(m.ID)
+	id, err := primitive.ObjectIDFromHex(e.Link.ID)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: link id: %w", eventbus.ErrPermanent, err)
 	}
 
 	link, err := s.repository.FindByID(ctx, id)
+	if errors.Is(err, database.ErrNotFound) {
+		slog.Info("link of event is deleted", slog.String("link_id", e.Link.ID))
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	parsed, err := scrape.Parse(ctx, link.URL)
+	// the counters of the previous checks are kept
+	now := time.Now()
+	enrichment := link.Enrichment
+	enrichment.Attempts = msg.Attempts + 1
+	enrichment.LastAttemptAt = &now
+	enrichment.HTTPStatus = 0
+	enrichment.RedirectURL = ""
+	enrichment.LastError = ""
+
+	page, err := s.scrape(ctx, link.URL)
 	if err != nil {
+		s.fail(ctx, link, enrichment, err)
 		return err
 	}
+	parsed := page.Meta
 
-	if parsed.Title != "" {
+	// the page fills in what the user left empty and never replaces what
+	// the user set
+	if link.Title == "" {
 		link.Title = parsed.Title
 	}
 
-	if len(parsed.Tags) > 0 {
-		link.Tags = append(link.Tags, parsed.Tags...)
+	// the tags of the user are kept and the ones of the page are added once
+	for _, tag := range parsed.Tags {
+		if !slices.Contains(link.Tags, tag) {
+			link.Tags = append(link.Tags, tag)
+		}
+	}
+
+	if len(link.Images) == 0 {
+		link.Images = parsed.Images
+	}
+
+	found := link.UpdatedAt
+	link.UpdatedAt = now
+	event, err := models.NewEvent(models.EventLinkEnriched, models.Actor{Service: serviceName}, link).OutboxEvent()
+	if err != nil {
+		return err
 	}
 
 	req := database.UpdateLinkReq{
-		ID:     id,
-		Title:  link.Title,
-		URL:    link.URL,
-		Images: link.Images,
-		Tags:   link.Tags,
-		UserID: link.UserID,
+		ID:         id,
+		Title:      link.Title,
+		URL:        link.URL,
+		Images:     link.Images,
+		Tags:       link.Tags,
+		UserID:     link.UserID,
+		Enrichment: ok(link, enrichment, page),
+		// an edit made during the scrape is not overwritten
+		UpdatedAt: &found,
+		Events:    []database.OutboxEvent{event},
 	}
 
 	_, err = s.repository.Update(ctx, req)
+	if errors.Is(err, database.ErrConflict) {
+		// the retry reads the link as the user left it
+		return fmt.Errorf("link %s is changed during the scrape: %w", e.Link.ID, err)
+	}
 	return err
 }
+
+// ok returns the enrichment of the link scraped successfully.
+func ok(link database.Link, e database.Enrichment, page *scrape.Page) *database.Enrichment {
+	e.State = database.EnrichmentOK
+	e.HTTPStatus = http.StatusOK
+	e.RedirectURL = redirectURL(link, page.URL)
+	e.ConsecutiveFailures = 0
+	e.Broken = false
+
+	return &e
+}
+
+// fail records the failed attempt on the link. The link stays pending while
+// the event is to be retried, and is skipped when the page cannot be scraped
+// at all or blocked when its site disallows it. Only the failed checks count
+// towards the link being broken.
+func (s *Story) fail(ctx context.Context, link database.Link, e database.Enrichment, err error) {
+	switch eventbus.Decide(ctx, s.cfg.Subscription, e.Attempts, err) {
+	case eventbus.Requeue:
+		// the attempt is interrupted rather than failed
+		return
+	case eventbus.DeadLetter:
+		if errors.Is(err, scrape.ErrRobotsDisallowed) {
+			e.State = database.EnrichmentBlocked
+			break
+		}
+		if errors.Is(err, eventbus.ErrPermanent) {
+			e.State = database.EnrichmentSkipped
+			break
+		}
+		e.State = database.EnrichmentFailed
+		e.ConsecutiveFailures++
+		e.Broken = s.cfg.BrokenAfter > 0 && e.ConsecutiveFailures >= s.cfg.BrokenAfter
+	default:
+		e.State = database.EnrichmentPending
+	}
+
+	e.LastError = err.Error()
+	var status *scrape.StatusError
+	if errors.As(err, &status) {
+		e.HTTPStatus = status.Code
+		e.RedirectURL = redirectURL(link, status.URL)
+	}
+
+	err = s.repository.SetEnrichment(ctx, link.ID, e)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		slog.Error("set enrichment", slog.String("link_id", link.ID.Hex()), slog.Any("err", err))
+	}
+}
+
+// redirectURL returns the URL the link is redirected to, or an empty string
+// if it is not.
+func redirectURL(link database.Link, final string) string {
+	if final == link.URL {
+		return ""
+	}
+
+	return final
+}
+
+// scrape fetches the page at rawURL within the limits of its host and the
+// Crawl-delay of its site.
+func (s *Story) scrape(ctx context.Context, rawURL string) (*scrape.Page, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: url Parse: %w", eventbus.ErrPermanent, err)
+	}
+
+	delay, err := s.scraper.CrawlDelay(ctx, rawURL)
+	if err != nil {
+		return nil, permanent(err)
+	}
+	s.hosts.SetMinInterval(u.Hostname(), delay)
+
+	release, err := s.hosts.Wait(ctx, u.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("wait for host %s: %w", u.Hostname(), err)
+	}
+	defer release()
+
+	page, err := s.scraper.Fetch(ctx, rawURL)
+	if err != nil {
+		return nil, permanent(err)
+	}
+
+	return page, nil
+}
+
+// permanent marks the scrape errors that the next attempt gets as well.
+func permanent(err error) error {
+	switch {
+	case errors.Is(err, scrape.ErrForbidden),
+		errors.Is(err, scrape.ErrTooManyRedirects),
+		errors.Is(err, scrape.ErrContentTypeDenied),
+		errors.Is(err, scrape.ErrRobotsDisallowed):
+		return fmt.Errorf("%w: %w", eventbus.ErrPermanent, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,352 @@
+package linkupdater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/eventbus"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/eventbus/memory"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/link/models"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/htmlmeta"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/scrape"
+)
+
+// fakeRepository finds the link of url, or none if it is empty, and records
+// the lookups and the enrichments.
+type fakeRepository struct {
+	url         string
+	found       chan primitive.ObjectID
+	enrichments chan database.Enrichment
+}
+
+func (r fakeRepository) FindByID(_ context.Context, id primitive.ObjectID) (database.Link, error) {
+	r.found <- id
+	if r.url == "" {
+		return database.Link{}, database.ErrNotFound
+	}
+	return database.Link{ID: id, URL: r.url}, nil
+}
+
+func (r fakeRepository) Update(context.Context, database.UpdateLinkReq) (database.Link, error) {
+	return database.Link{}, nil
+}
+
+func (r fakeRepository) SetEnrichment(
+	_ context.Context, _ primitive.ObjectID, e database.Enrichment, _ ...database.OutboxEvent,
+) error {
+	r.enrichments <- e
+	return nil
+}
+
+func TestStoryRun(t *testing.T) {
+	id := primitive.NewObjectID()
+	valid, err := json.Marshal(models.Event{Type: models.EventLinkCreated, Link: models.Link{ID: id.Hex()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		body   []byte
+		url    string
+		found  bool
+		dead   bool
+		ignore bool
+		state  database.EnrichmentState
+	}{
+		{
+			name:  "test_deleted_link_is_dropped",
+			key:   models.EventLinkCreated,
+			body:  valid,
+			found: true,
+		},
+		{
+			name: "test_invalid_event_is_dead_lettered",
+			key:  models.EventLinkCreated,
+			body: []byte("not json"),
+			dead: true,
+		},
+		{
+			name:  "test_forbidden_link_is_skipped",
+			key:   models.EventLinkRefreshRequested,
+			body:  valid,
+			url:   "http://127.0.0.1/",
+			found: true,
+			dead:  true,
+			state: database.EnrichmentSkipped,
+		},
+		{
+			name:   "test_enriched_event_is_not_consumed",
+			key:    models.EventLinkEnriched,
+			body:   valid,
+			ignore: true,
+		},
+		{
+			name:   "test_updated_event_is_not_consumed",
+			key:    models.EventLinkUpdated,
+			body:   valid,
+			ignore: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := memory.New()
+			repo := fakeRepository{
+				url:         tt.url,
+				found:       make(chan primitive.ObjectID, 1),
+				enrichments: make(chan database.Enrichment, 1),
+			}
+			s := New(repo, bus, scrape.New(scrape.DefaultConfig()), Config{
+				Subscription: eventbus.Subscription{Queue: "links", MaxAttempts: 3},
+			})
+
+			if err := bus.Declare(s.Subscription()); err != nil {
+				t.Fatal(err)
+			}
+			if err := bus.Publish(context.Background(), eventbus.Event{Key: tt.key, Body: tt.body}); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() { done <- s.Run(ctx) }()
+
+			if tt.found {
+				select {
+				case got := <-repo.found:
+					if got != id {
+						t.Errorf("found link %s, want %s", got.Hex(), id.Hex())
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatal("link is not looked up")
+				}
+			}
+			deadline := time.Now().Add(5 * time.Second)
+			for tt.dead && len(bus.DeadLetters("links")) == 0 && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			if tt.ignore {
+				time.Sleep(20 * time.Millisecond)
+			}
+
+			cancel()
+			if err := <-done; err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if got := len(bus.DeadLetters("links")); (got == 1) != tt.dead {
+				t.Errorf("dead letters = %d, want dead-lettered %v", got, tt.dead)
+			}
+			if tt.ignore && len(repo.found) != 0 {
+				t.Errorf("%s event is consumed", tt.key)
+			}
+			if tt.state != "" {
+				select {
+				case e := <-repo.enrichments:
+					if e.State != tt.state || e.Attempts != 1 || e.LastError == "" {
+						t.Errorf("enrichment = %+v, want state %s after 1 attempt with an error", e, tt.state)
+					}
+				default:
+					t.Error("enrichment is not set")
+				}
+			}
+		})
+	}
+}
+
+func TestStoryFail(t *testing.T) {
+	link := database.Link{ID: primitive.NewObjectID(), URL: "https://example.com/old"}
+	gone := &scrape.StatusError{Code: 404, URL: "https://example.com/new"}
+
+	tests := []struct {
+		name     string
+		attempts int
+		err      error
+		want     database.Enrichment
+	}{
+		{
+			name:     "test_retried_attempt_keeps_link_pending",
+			attempts: 1,
+			err:      &scrape.StatusError{Code: 503, URL: link.URL},
+			want: database.Enrichment{
+				State: database.EnrichmentPending, Attempts: 1, HTTPStatus: 503, ConsecutiveFailures: 2,
+			},
+		},
+		{
+			name:     "test_failed_check_breaks_link",
+			attempts: 3,
+			err:      gone,
+			want: database.Enrichment{
+				State: database.EnrichmentFailed, Attempts: 3, HTTPStatus: 404,
+				RedirectURL: gone.URL, ConsecutiveFailures: 3, Broken: true,
+			},
+		},
+		{
+			name:     "test_skipped_check_is_not_failure",
+			attempts: 1,
+			err:      fmt.Errorf("%w: %w", eventbus.ErrPermanent, scrape.ErrContentTypeDenied),
+			want: database.Enrichment{
+				State: database.EnrichmentSkipped, Attempts: 1, ConsecutiveFailures: 2,
+			},
+		},
+		{
+			name:     "test_disallowed_link_is_blocked",
+			attempts: 1,
+			err:      permanent(fmt.Errorf("%w: %s", scrape.ErrRobotsDisallowed, link.URL)),
+			want: database.Enrichment{
+				State: database.EnrichmentBlocked, Attempts: 1, ConsecutiveFailures: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := fakeRepository{enrichments: make(chan database.Enrichment, 1)}
+			s := New(repo, memory.New(), nil, Config{
+				Subscription: eventbus.Subscription{MaxAttempts: 3},
+				BrokenAfter:  3,
+			})
+
+			s.fail(context.Background(), link, database.Enrichment{Attempts: tt.attempts, ConsecutiveFailures: 2}, tt.err)
+
+			got := <-repo.enrichments
+			if got.LastError == "" {
+				t.Error("last error is not set")
+			}
+			got.LastError = ""
+			if got != tt.want {
+				t.Errorf("enrichment = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// storedRepository holds one link, which the user may edit while it is
+// scraped, and applies the updates as the links repository does.
+type storedRepository struct {
+	link database.Link
+	// edit is applied to the link once it is found
+	edit func(l *database.Link)
+}
+
+func (r *storedRepository) FindByID(_ context.Context, id primitive.ObjectID) (database.Link, error) {
+	found := r.link
+	if r.edit != nil {
+		r.edit(&r.link)
+		r.edit = nil
+	}
+	return found, nil
+}
+
+func (r *storedRepository) Update(_ context.Context, req database.UpdateLinkReq) (database.Link, error) {
+	if req.UpdatedAt != nil && !req.UpdatedAt.Equal(r.link.UpdatedAt) {
+		return database.Link{}, database.ErrConflict
+	}
+	r.link.Title, r.link.Images, r.link.Tags = req.Title, req.Images, req.Tags
+	r.link.UpdatedAt = time.Now()
+	return r.link, nil
+}
+
+func (r *storedRepository) SetEnrichment(
+	context.Context, primitive.ObjectID, database.Enrichment, ...database.OutboxEvent,
+) error {
+	return nil
+}
+
+type fakeScraper struct {
+	meta *htmlmeta.Meta
+}
+
+func (s fakeScraper) Fetch(_ context.Context, url string) (*scrape.Page, error) {
+	return &scrape.Page{URL: url, Meta: s.meta}, nil
+}
+
+func (s fakeScraper) CrawlDelay(context.Context, string) (time.Duration, error) {
+	return 0, nil
+}
+
+func TestStoryKeepsUserFields(t *testing.T) {
+	page := &htmlmeta.Meta{Title: "Example Domain", Tags: []string{"example"}, Images: []string{"https://example.com/og.png"}}
+	edited := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name   string
+		link   database.Link
+		edit   func(l *database.Link)
+		title  string
+		images []string
+	}{
+		{
+			name:   "test_empty_fields_are_filled",
+			link:   database.Link{URL: "https://example.com"},
+			title:  "Example Domain",
+			images: page.Images,
+		},
+		{
+			name: "test_edited_title_survives",
+			link: database.Link{
+				URL:       "https://example.com",
+				Title:     "My favourite example",
+				Images:    []string{"https://example.com/mine.png"},
+				UpdatedAt: edited,
+			},
+			title:  "My favourite example",
+			images: []string{"https://example.com/mine.png"},
+		},
+		{
+			name: "test_title_edited_during_scrape_survives",
+			link: database.Link{URL: "https://example.com", UpdatedAt: edited},
+			edit: func(l *database.Link) {
+				l.Title = "Edited meanwhile"
+				l.UpdatedAt = time.Now()
+			},
+			title: "Edited meanwhile",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.link.ID = primitive.NewObjectID()
+			repo := &storedRepository{link: tt.link, edit: tt.edit}
+			s := New(repo, memory.New(), fakeScraper{meta: page}, Config{
+				Subscription: eventbus.Subscription{Queue: "links", MaxAttempts: 3},
+			})
+
+			body, err := json.Marshal(models.Event{Type: models.EventLinkCreated, Link: models.Link{ID: tt.link.ID.Hex()}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			msg := eventbus.Event{Key: models.EventLinkCreated, Body: body}
+
+			err = s.handle(context.Background(), msg)
+			if tt.edit != nil {
+				if !errors.Is(err, database.ErrConflict) {
+					t.Fatalf("handle() error = %v, want %v", err, database.ErrConflict)
+				}
+				// the retry of the event
+				err = s.handle(context.Background(), msg)
+			}
+			if err != nil {
+				t.Fatalf("handle() error = %v", err)
+			}
+
+			if repo.link.Title != tt.title {
+				t.Errorf("title = %q, want %q", repo.link.Title, tt.title)
+			}
+			if tt.images != nil && fmt.Sprint(repo.link.Images) != fmt.Sprint(tt.images) {
+				t.Errorf("images = %v, want %v", repo.link.Images, tt.images)
+			}
+			if !slices.Contains(repo.link.Tags, "example") {
+				t.Errorf("tags = %v, want the tags of the page added", repo.link.Tags)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package outboxrelay
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/eventbus"
+)
+
+type repository interface {
+	FindPending(ctx context.Context, after primitive.ObjectID, limit int64) ([]database.OutboxEvent, error)
+	MarkSent(ctx context.Context, id primitive.ObjectID) error
+	MarkFailed(ctx context.Context, id primitive.ObjectID, cause error) error
+}
+
+type publisher interface {
+	Publish(ctx context.Context, e eventbus.Event) error
+}
+
+type Config struct {
+	// Interval is how often the outbox is polled for pending events.
+	Interval  time.Duration
+	BatchSize int64
+}
+
+func New(repository repository, publisher publisher, cfg Config) *Story {
+	return &Story{
+		repository: repository,
+		publisher:  publisher,
+		cfg:        cfg,
+	}
+}
+
+// Story publishes the pending outbox events and marks them sent once the bus
+// accepts them. Events are published at least once: an event whose
+// confirmation is lost is published again.
+type Story struct {
+	repository repository
+	publisher  publisher
+	cfg        Config
+}
+
+// Run relays the outbox every Interval until ctx is done.
+func (s *Story) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Relay(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("relay outbox", slog.Any("err", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Relay publishes the pending events in the order of creation. An event that
+// fails to publish has the failure recorded and is skipped, so that it does
+// not hold back the rest. It is published again by the next Relay.
+func (s *Story) Relay(ctx context.Context) error {
+	var (
+		after   primitive.ObjectID
+		failed  int
+		lastErr error
+	)
+	for {
+		events, err := s.repository.FindPending(ctx, after, s.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("outbox FindPending: %w", err)
+		}
+
+		for _, e := range events {
+			after = e.ID
+
+			if err := s.publish(ctx, e); err != nil {
+				if ctx.Err() != nil {
+					return fmt.Errorf("publish event %s: %w", e.ID.Hex(), err)
+				}
+
+				slog.Error("publish outbox event", slog.String("id", e.ID.Hex()), slog.Any("err", err))
+				if err := s.repository.MarkFailed(ctx, e.ID, err); err != nil {
+					slog.Error("outbox MarkFailed", slog.Any("err", err))
+				}
+				failed++
+				lastErr = err
+				continue
+			}
+
+			if err := s.repository.MarkSent(ctx, e.ID); err != nil {
+				return fmt.Errorf("outbox MarkSent: %w", err)
+			}
+		}
+
+		if int64(len(events)) < s.cfg.BatchSize {
+			break
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d events are not published, the last: %w", failed, lastErr)
+	}
+
+	return nil
+}
+
+func (s *Story) publish(ctx context.Context, e database.OutboxEvent) error {
+	return s.publisher.Publish(ctx, eventbus.Event{
+		ID:          e.ID.Hex(),
+		Key:         e.RoutingKey,
+		ContentType: e.ContentType,
+		Body:        e.Body,
+		Time:        e.CreatedAt,
+	})
+}
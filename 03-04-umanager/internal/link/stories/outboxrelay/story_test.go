@@ -0,0 +1,113 @@
+package outboxrelay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/eventbus"
+)
+
+type fakeRepository struct {
+	pending []database.OutboxEvent
+	sent    []primitive.ObjectID
+	failed  []primitive.ObjectID
+}
+
+func (r *fakeRepository) FindPending(_ context.Context, after primitive.ObjectID, limit int64) ([]database.OutboxEvent, error) {
+	var res []database.OutboxEvent
+	for _, e := range r.pending {
+		if int64(len(res)) == limit {
+			break
+		}
+		if after.IsZero() || e.ID.Hex() > after.Hex() {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeRepository) MarkSent(_ context.Context, id primitive.ObjectID) error {
+	r.sent = append(r.sent, id)
+	return nil
+}
+
+func (r *fakeRepository) MarkFailed(_ context.Context, id primitive.ObjectID, _ error) error {
+	r.failed = append(r.failed, id)
+	return nil
+}
+
+// fakePublisher fails to publish the events with the keys in fail.
+type fakePublisher struct {
+	fail      map[string]error
+	published []string
+}
+
+func (p *fakePublisher) Publish(_ context.Context, e eventbus.Event) error {
+	p.published = append(p.published, e.Key)
+	return p.fail[e.Key]
+}
+
+func TestStoryRelayFailure(t *testing.T) {
+	tests := []struct {
+		name      string
+		batchSize int64
+		fail      map[string]error
+		sent      []int
+		failed    []int
+	}{
+		{
+			name:      "test_publish_error_skips_the_event",
+			batchSize: 10,
+			fail:      map[string]error{"event-0": errors.New("amqp connection is down")},
+			sent:      []int{1, 2},
+			failed:    []int{0},
+		},
+		{
+			name:      "test_nacked_event_does_not_block_the_next_batches",
+			batchSize: 1,
+			fail:      map[string]error{"event-1": errors.New("event is nacked by the broker")},
+			sent:      []int{0, 2},
+			failed:    []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []primitive.ObjectID
+			repo := &fakeRepository{}
+			for i := 0; i < 3; i++ {
+				id := primitive.NewObjectID()
+				ids = append(ids, id)
+				repo.pending = append(repo.pending, database.OutboxEvent{ID: id, RoutingKey: fmt.Sprintf("event-%d", i)})
+			}
+			pub := &fakePublisher{fail: tt.fail}
+
+			err := New(repo, pub, Config{BatchSize: tt.batchSize}).Relay(context.Background())
+			if err == nil {
+				t.Fatal("Relay() error = nil, want the failure reported")
+			}
+
+			if len(pub.published) != len(ids) {
+				t.Errorf("published = %v, want every event tried once", pub.published)
+			}
+			if want := pick(ids, tt.sent); fmt.Sprint(repo.sent) != fmt.Sprint(want) {
+				t.Errorf("sent = %v, want %v", repo.sent, want)
+			}
+			if want := pick(ids, tt.failed); fmt.Sprint(repo.failed) != fmt.Sprint(want) {
+				t.Errorf("failed = %v, want %v", repo.failed, want)
+			}
+		})
+	}
+}
+
+func pick(ids []primitive.ObjectID, indexes []int) []primitive.ObjectID {
+	res := make([]primitive.ObjectID, len(indexes))
+	for i, idx := range indexes {
+		res[i] = ids[idx]
+	}
+	return res
+}
@@ -0,0 +1,103 @@
+package usergrpc
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/grpcerr"
+)
+
+// Permissions required by the UserService methods, see auth.UnaryServerInterceptor.
+// CreateUser is the public sign-up and is left out on purpose.
+var Permissions = map[string]string{
+	"/pb.UserService/GetUser":    auth.PermissionUsersRead,
+	"/pb.UserService/UpdateUser": auth.PermissionUsersWrite,
+	"/pb.UserService/DeleteUser": auth.PermissionUsersWrite,
+	"/pb.UserService/ListUsers":  auth.PermissionUsersManage,
+
+	"/pb.UserService/CreateAPIKey": auth.PermissionUsersWrite,
+	"/pb.UserService/ListAPIKeys":  auth.PermissionUsersRead,
+	"/pb.UserService/RevokeAPIKey": auth.PermissionUsersWrite,
+}
+
+var (
+	errPermissionDenied = status.Error(codes.PermissionDenied, "permission denied")
+	errTokenRequired    = status.Error(codes.PermissionDenied, "an access token is required, not an api key")
+)
+
+// authorize checks that the caller may access the account with the given ID.
+func authorize(ctx context.Context, userID uuid.UUID) error {
+	c, ok := auth.IdentityFromContext(ctx)
+	if !ok || !c.CanAccess(userID.String(), auth.PermissionUsersManage) {
+		return errPermissionDenied
+	}
+
+	return nil
+}
+
+// requireToken rejects the callers authenticated with an API key. The calls
+// that take over the account or mint new credentials need an access token, so
+// that a leaked key grants no more than the key itself.
+func requireToken(ctx context.Context) error {
+	if c, _ := auth.IdentityFromContext(ctx); c.APIKey {
+		return errTokenRequired
+	}
+
+	return nil
+}
+
+// callerID returns the ID of the user making the call.
+func callerID(ctx context.Context) (uuid.UUID, error) {
+	c, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+
+	return uuid.Parse(c.UserID)
+}
+
+// authorizeRoles checks that the caller may grant roles and that all of them exist.
+func (h Handler) authorizeRoles(ctx context.Context, roles []string) error {
+	c, ok := auth.IdentityFromContext(ctx)
+	if !ok || !c.Can(auth.PermissionUsersManage) {
+		return errPermissionDenied
+	}
+
+	found, err := h.usersRepository.FindRoles(ctx, roles)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range roles {
+		if !slices.ContainsFunc(found, func(r database.Role) bool { return r.Name == name }) {
+			return grpcerr.InvalidArgument("roles", "unknown role "+name)
+		}
+	}
+
+	return nil
+}
+
+// permissions returns the permissions granted to the roles.
+func (h Handler) permissions(ctx context.Context, roles []string) ([]string, error) {
+	found, err := h.usersRepository.FindRoles(ctx, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []string
+	for _, r := range found {
+		for _, p := range r.Permissions {
+			if !slices.Contains(res, p) {
+				res = append(res, p)
+			}
+		}
+	}
+
+	return res, nil
+}
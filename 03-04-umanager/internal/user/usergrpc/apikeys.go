@@ -0,0 +1,172 @@
+package usergrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/grpcerr"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
+)
+
+var errInvalidAPIKey = status.Error(codes.Unauthenticated, "invalid api key")
+
+func (h Handler) CreateAPIKey(ctx context.Context, in *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireToken(ctx); err != nil {
+		return nil, err
+	}
+
+	if in.Name == "" {
+		return nil, grpcerr.InvalidArgument("name", "is required")
+	}
+
+	scopes := in.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{auth.ScopeFull}
+	}
+	for _, scope := range scopes {
+		if !auth.ValidScope(scope) {
+			return nil, grpcerr.InvalidArgument("scopes", "unknown scope "+scope)
+		}
+	}
+
+	var expiresAt *time.Time
+	if in.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, in.ExpiresAt)
+		if err != nil {
+			return nil, grpcerr.InvalidArgument("expires_at", "must be an RFC 3339 date-time")
+		}
+		if t.Before(time.Now()) {
+			return nil, grpcerr.InvalidArgument("expires_at", "is in the past")
+		}
+		expiresAt = &t
+	}
+
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := h.apiKeysRepository.Create(ctx, database.CreateAPIKeyReq{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      in.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("api keys repository Create: %w", err)
+	}
+
+	return &pb.CreateAPIKeyResponse{ApiKey: apiKeyToPB(k), Key: key}, nil
+}
+
+func (h Handler) ListAPIKeys(ctx context.Context, _ *pb.Empty) (*pb.ListAPIKeysResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := h.apiKeysRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*pb.APIKey, len(keys))
+	for i, k := range keys {
+		res[i] = apiKeyToPB(k)
+	}
+	return &pb.ListAPIKeysResponse{ApiKeys: res}, nil
+}
+
+func (h Handler) RevokeAPIKey(ctx context.Context, in *pb.RevokeAPIKeyRequest) (*pb.Empty, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+
+	id, err := uuid.Parse(in.Id)
+	if err != nil {
+		return &pb.Empty{}, status.Error(codes.NotFound, "api key not found")
+	}
+
+	err = h.apiKeysRepository.Delete(ctx, id, userID)
+	if errors.Is(err, database.ErrNotFound) {
+		return &pb.Empty{}, status.Error(codes.NotFound, "api key not found")
+	}
+	return &pb.Empty{}, err
+}
+
+// VerifyAPIKey is called by the api-gw to authenticate requests made with an
+// API key. The key grants the permissions of its owner limited by its scopes.
+func (h Handler) VerifyAPIKey(ctx context.Context, in *pb.VerifyAPIKeyRequest) (*pb.VerifyAPIKeyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	if !auth.IsAPIKey(in.Key) {
+		return nil, errInvalidAPIKey
+	}
+
+	k, err := h.apiKeysRepository.Use(ctx, auth.HashAPIKey(in.Key))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, errInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	user, err := h.usersRepository.FindByID(ctx, k.UserID)
+	if err != nil {
+		return nil, errInvalidAPIKey
+	}
+
+	permissions, err := h.permissions(ctx, user.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.VerifyAPIKeyResponse{
+		UserId:      user.ID.String(),
+		Roles:       user.Roles,
+		Permissions: auth.ScopePermissions(k.Scopes, permissions),
+	}, nil
+}
+
+func apiKeyToPB(k database.APIKey) *pb.APIKey {
+	res := &pb.APIKey{
+		Id:        k.ID.String(),
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt.String(),
+	}
+	if k.ExpiresAt != nil {
+		res.ExpiresAt = k.ExpiresAt.Format(time.RFC3339)
+	}
+	if k.LastUsedAt != nil {
+		res.LastUsedAt = k.LastUsedAt.Format(time.RFC3339)
+	}
+	return res
+}
@@ -0,0 +1,146 @@
+package usergrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
+)
+
+var errInvalidCredentials = status.Error(codes.Unauthenticated, "invalid username or password")
+
+var errInvalidRefreshToken = status.Error(codes.Unauthenticated, "invalid refresh token")
+
+func (h Handler) Login(ctx context.Context, in *pb.LoginRequest) (*pb.TokenResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	if in.Username == "" || in.Password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	user, err := h.Authenticate(ctx, in.Username, in.Password)
+	if err != nil {
+		slog.Info("login failed", slog.String("username", in.Username), slog.Any("err", err))
+		return nil, errInvalidCredentials
+	}
+
+	token, hash, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = h.tokensRepository.Create(ctx, database.CreateRefreshTokenReq{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		FamilyID:  uuid.New(),
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(h.refreshTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tokens repository Create: %w", err)
+	}
+
+	return h.tokenResponse(ctx, user, token)
+}
+
+// Refresh exchanges a refresh token for a new token pair. Every refresh token
+// can be used once: presenting an already rotated token revokes the whole
+// session, since it means the token has leaked.
+func (h Handler) Refresh(ctx context.Context, in *pb.RefreshRequest) (*pb.TokenResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	old, err := h.tokensRepository.FindByHash(ctx, auth.HashRefreshToken(in.RefreshToken))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, errInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if old.RevokedAt != nil {
+		if old.ReplacedBy != nil {
+			slog.Warn("refresh token reuse detected", slog.String("family_id", old.FamilyID.String()))
+			if err := h.tokensRepository.RevokeFamily(ctx, old.FamilyID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, errInvalidRefreshToken
+	}
+
+	if time.Now().After(old.ExpiresAt) {
+		return nil, errInvalidRefreshToken
+	}
+
+	user, err := h.usersRepository.FindByID(ctx, old.UserID)
+	if err != nil {
+		return nil, errInvalidRefreshToken
+	}
+
+	token, hash, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = h.tokensRepository.Rotate(ctx, old.ID, database.CreateRefreshTokenReq{
+		ID:        uuid.New(),
+		UserID:    old.UserID,
+		FamilyID:  old.FamilyID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(h.refreshTTL),
+	})
+	if err != nil {
+		if errors.Is(err, database.ErrConflict) {
+			return nil, errInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("tokens repository Rotate: %w", err)
+	}
+
+	return h.tokenResponse(ctx, user, token)
+}
+
+func (h Handler) Logout(ctx context.Context, in *pb.LogoutRequest) (*pb.Empty, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	t, err := h.tokensRepository.FindByHash(ctx, auth.HashRefreshToken(in.RefreshToken))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return &pb.Empty{}, nil
+		}
+		return &pb.Empty{}, err
+	}
+
+	return &pb.Empty{}, h.tokensRepository.RevokeFamily(ctx, t.FamilyID)
+}
+
+func (h Handler) tokenResponse(
+	ctx context.Context, user database.User, refreshToken string,
+) (*pb.TokenResponse, error) {
+	permissions, err := h.permissions(ctx, user.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := h.issuer.Issue(user.ID, user.Username, user.Roles, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    auth.TokenType,
+		ExpiresIn:    int64(h.issuer.AccessTTL().Seconds()),
+	}, nil
+}
@@ -2,6 +2,7 @@ package usergrpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -10,7 +11,37 @@ import (
 
 type usersRepository interface {
 	Create(ctx context.Context, req database.CreateUserReq) (database.User, error)
+	Update(ctx context.Context, req database.UpdateUserReq) (database.User, error)
+	UpdatePassword(ctx context.Context, userID uuid.UUID, password string) error
 	FindByID(ctx context.Context, userID uuid.UUID) (database.User, error)
+	FindByUsername(ctx context.Context, username string) (database.User, error)
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
-	FindAll(ctx context.Context) ([]database.User, error)
+	FindByCriteria(ctx context.Context, criteria database.FindUserCriteria) ([]database.User, error)
+	FindRoles(ctx context.Context, names []string) ([]database.Role, error)
+}
+
+type passwordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encoded string) error
+	NeedsRehash(encoded string) bool
+}
+
+type tokensRepository interface {
+	Create(ctx context.Context, req database.CreateRefreshTokenReq) (database.RefreshToken, error)
+	FindByHash(ctx context.Context, tokenHash string) (database.RefreshToken, error)
+	Rotate(ctx context.Context, oldID uuid.UUID, req database.CreateRefreshTokenReq) (database.RefreshToken, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+	RevokeByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type apiKeysRepository interface {
+	Create(ctx context.Context, req database.CreateAPIKeyReq) (database.APIKey, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]database.APIKey, error)
+	Use(ctx context.Context, keyHash string) (database.APIKey, error)
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type tokenIssuer interface {
+	Issue(userID uuid.UUID, username string, roles, permissions []string) (string, error)
+	AccessTTL() time.Duration
 }
@@ -2,47 +2,78 @@ package usergrpc
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/internal/grpcerr"
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/pb"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
 var _ pb.UserServiceServer = (*Handler)(nil)
 
-func New(usersRepository usersRepository, timeout time.Duration) *Handler {
-	return &Handler{usersRepository: usersRepository, timeout: timeout}
+func New(
+	usersRepository usersRepository,
+	tokensRepository tokensRepository,
+	apiKeysRepository apiKeysRepository,
+	hasher passwordHasher,
+	issuer tokenIssuer,
+	refreshTTL time.Duration,
+	timeout time.Duration,
+) *Handler {
+	return &Handler{
+		usersRepository:   usersRepository,
+		tokensRepository:  tokensRepository,
+		apiKeysRepository: apiKeysRepository,
+		hasher:            hasher,
+		issuer:            issuer,
+		refreshTTL:        refreshTTL,
+		timeout:           timeout,
+	}
 }
 
 type Handler struct {
 	pb.UnimplementedUserServiceServer
-	usersRepository usersRepository
-	timeout         time.Duration
+	usersRepository   usersRepository
+	tokensRepository  tokensRepository
+	apiKeysRepository apiKeysRepository
+	hasher            passwordHasher
+	issuer            tokenIssuer
+	refreshTTL        time.Duration
+	timeout           time.Duration
 }
 
 func (h Handler) CreateUser(ctx context.Context, in *pb.CreateUserRequest) (*pb.Empty, error) {
 	ctx, cancel := context.WithTimeout(ctx, h.timeout)
 	defer cancel()
 
-	var (
-		id  uuid.UUID
-		err error
-	)
-	if in.Id == "" {
-		id = uuid.New()
-	} else {
-		id, err = uuid.Parse(in.Id)
+	var violations []*errdetails.BadRequest_FieldViolation
+	// the ID is generated, so that a sign-up cannot take over an account
+	if in.Id != "" {
+		violations = append(violations, grpcerr.Violation("id", "must be empty, it is generated"))
+	}
+	if in.Username == "" {
+		violations = append(violations, grpcerr.Violation("username", "is required"))
+	}
+	if in.Password == "" {
+		violations = append(violations, grpcerr.Violation("password", "is required"))
+	}
+	if len(violations) > 0 {
+		return &pb.Empty{}, grpcerr.BadRequest(violations...)
 	}
 
+	hash, err := h.hashPassword(in.Password)
 	if err != nil {
 		return &pb.Empty{}, err
 	}
 
 	req := database.CreateUserReq{
-		ID:       id,
+		ID:       uuid.New(),
 		Username: in.Username,
-		Password: in.Password,
+		Password: hash,
 	}
 	_, err = h.usersRepository.Create(ctx, req)
 	return &pb.Empty{}, err
@@ -54,6 +85,10 @@ func (h Handler) GetUser(ctx context.Context, in *pb.GetUserRequest) (*pb.User,
 
 	id, err := uuid.Parse(in.Id)
 	if err != nil {
+		return nil, grpcerr.InvalidArgument("id", "must be a UUID")
+	}
+
+	if err := authorize(ctx, id); err != nil {
 		return nil, err
 	}
 
@@ -64,7 +99,7 @@ func (h Handler) GetUser(ctx context.Context, in *pb.GetUserRequest) (*pb.User,
 	return &pb.User{
 		Id:        user.ID.String(),
 		Username:  user.Username,
-		Password:  user.Password,
+		Roles:     user.Roles,
 		CreatedAt: user.CreatedAt.String(),
 		UpdatedAt: user.UpdatedAt.String(),
 	}, nil
@@ -76,14 +111,45 @@ func (h Handler) UpdateUser(ctx context.Context, in *pb.UpdateUserRequest) (*pb.
 
 	id, err := uuid.Parse(in.Id)
 	if err != nil {
+		return &pb.Empty{}, grpcerr.InvalidArgument("id", "must be a UUID")
+	}
+
+	if err := authorize(ctx, id); err != nil {
 		return &pb.Empty{}, err
 	}
-	req := database.CreateUserReq{
+
+	if in.Password != "" || len(in.Roles) > 0 {
+		if err := requireToken(ctx); err != nil {
+			return &pb.Empty{}, err
+		}
+	}
+
+	if len(in.Roles) > 0 {
+		if err := h.authorizeRoles(ctx, in.Roles); err != nil {
+			return &pb.Empty{}, err
+		}
+	}
+
+	hash, err := h.hashPassword(in.Password)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+
+	req := database.UpdateUserReq{
 		ID:       id,
 		Username: in.Username,
-		Password: in.Password,
+		Password: hash,
+		Roles:    in.Roles,
 	}
-	_, err = h.usersRepository.Create(ctx, req)
+	if _, err = h.usersRepository.Update(ctx, req); err != nil {
+		return &pb.Empty{}, err
+	}
+
+	if hash != "" {
+		// a new password invalidates every session opened with the old one
+		err = h.tokensRepository.RevokeByUserID(ctx, id)
+	}
+
 	return &pb.Empty{}, err
 }
 
@@ -93,6 +159,13 @@ func (h Handler) DeleteUser(ctx context.Context, in *pb.DeleteUserRequest) (*pb.
 
 	id, err := uuid.Parse(in.Id)
 	if err != nil {
+		return &pb.Empty{}, grpcerr.InvalidArgument("id", "must be a UUID")
+	}
+
+	if err := authorize(ctx, id); err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := requireToken(ctx); err != nil {
 		return &pb.Empty{}, err
 	}
 
@@ -100,25 +173,85 @@ func (h Handler) DeleteUser(ctx context.Context, in *pb.DeleteUserRequest) (*pb.
 	return &pb.Empty{}, err
 }
 
-func (h Handler) ListUsers(ctx context.Context, in *pb.Empty) (*pb.ListUsersResponse, error) {
+func (h Handler) ListUsers(ctx context.Context, in *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, h.timeout)
 	defer cancel()
 
-	users, err := h.usersRepository.FindAll(ctx)
+	page, err := database.ParsePage(in.PageSize, in.PageToken, in.Sort)
+	if err != nil {
+		return &pb.ListUsersResponse{}, err
+	}
+
+	// one extra user tells whether there is a next page
+	limit := page.Limit + 1
+	users, err := h.usersRepository.FindByCriteria(ctx, database.FindUserCriteria{
+		Limit:    &limit,
+		After:    page.After,
+		SortDesc: page.SortDesc,
+	})
 	if err != nil {
 		return &pb.ListUsersResponse{}, err
 	}
 
+	var next string
+	if int64(len(users)) > page.Limit {
+		users = users[:page.Limit]
+		last := users[len(users)-1]
+		next = database.EncodeCursor(database.Cursor{CreatedAt: last.CreatedAt, ID: last.ID.String()})
+	}
+
 	res := make([]*pb.User, len(users))
 	for i, u := range users {
 		res[i] = &pb.User{
 			Id:        u.ID.String(),
 			Username:  u.Username,
-			Password:  u.Password,
+			Roles:     u.Roles,
 			CreatedAt: u.CreatedAt.String(),
 			UpdatedAt: u.UpdatedAt.String(),
 		}
 	}
-	return &pb.ListUsersResponse{Users: res}, err
+	return &pb.ListUsersResponse{Users: res, NextPageToken: next}, nil
 }
 
+// Authenticate checks the password of the user with the given username. The
+// stored hash is upgraded when it was produced with outdated parameters.
+func (h Handler) Authenticate(ctx context.Context, username, password string) (database.User, error) {
+	user, err := h.usersRepository.FindByUsername(ctx, username)
+	if err != nil {
+		return user, err
+	}
+
+	if err := h.hasher.Verify(password, user.Password); err != nil {
+		return user, fmt.Errorf("hasher Verify: %w", err)
+	}
+
+	if h.hasher.NeedsRehash(user.Password) {
+		hash, err := h.hasher.Hash(password)
+		if err != nil {
+			slog.Error("rehash password", slog.Any("err", err))
+			return user, nil
+		}
+
+		if err := h.usersRepository.UpdatePassword(ctx, user.ID, hash); err != nil {
+			slog.Error("update rehashed password", slog.Any("err", err))
+			return user, nil
+		}
+
+		user.Password = hash
+	}
+
+	return user, nil
+}
+
+func (h Handler) hashPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	hash, err := h.hasher.Hash(password)
+	if err != nil {
+		return "", fmt.Errorf("hasher Hash: %w", err)
+	}
+
+	return hash, nil
+}
@@ -0,0 +1,220 @@
+package usergrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/auth"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/password"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/pb"
+)
+
+// fakeUsersRepository records the changes of the users. The methods the
+// tests do not reach are left to the embedded interface.
+type fakeUsersRepository struct {
+	usersRepository
+	user      database.User
+	updated   []database.UpdateUserReq
+	deleted   []uuid.UUID
+	passwords []string
+}
+
+func (r *fakeUsersRepository) FindByUsername(_ context.Context, username string) (database.User, error) {
+	if username != r.user.Username {
+		return database.User{}, database.ErrNotFound
+	}
+	return r.user, nil
+}
+
+func (r *fakeUsersRepository) UpdatePassword(_ context.Context, _ uuid.UUID, password string) error {
+	r.passwords = append(r.passwords, password)
+	r.user.Password = password
+	return nil
+}
+
+func (r *fakeUsersRepository) Update(_ context.Context, req database.UpdateUserReq) (database.User, error) {
+	r.updated = append(r.updated, req)
+	return database.User{ID: req.ID}, nil
+}
+
+func (r *fakeUsersRepository) DeleteByUserID(_ context.Context, id uuid.UUID) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func (r *fakeUsersRepository) FindRoles(_ context.Context, names []string) ([]database.Role, error) {
+	res := make([]database.Role, len(names))
+	for i, name := range names {
+		res[i] = database.Role{Name: name}
+	}
+	return res, nil
+}
+
+type fakeTokensRepository struct {
+	tokensRepository
+	revoked []uuid.UUID
+}
+
+func (r *fakeTokensRepository) RevokeByUserID(_ context.Context, id uuid.UUID) error {
+	r.revoked = append(r.revoked, id)
+	return nil
+}
+
+type fakeHasher struct{}
+
+func (fakeHasher) Hash(password string) (string, error) {
+	return "hash:" + password, nil
+}
+
+func (fakeHasher) Verify(string, string) error {
+	return nil
+}
+
+func (fakeHasher) NeedsRehash(string) bool {
+	return false
+}
+
+func TestHandlerAPIKeyCannotTakeOverAccount(t *testing.T) {
+	id := uuid.New()
+	permissions := []string{auth.PermissionUsersRead, auth.PermissionUsersWrite, auth.PermissionUsersManage}
+	token := auth.Identity{UserID: id.String(), Permissions: permissions}
+	apiKey := auth.Identity{UserID: id.String(), Permissions: permissions, APIKey: true}
+
+	update := func(in *pb.UpdateUserRequest) func(h Handler, ctx context.Context) error {
+		return func(h Handler, ctx context.Context) error {
+			_, err := h.UpdateUser(ctx, in)
+			return err
+		}
+	}
+	deleteUser := func(h Handler, ctx context.Context) error {
+		_, err := h.DeleteUser(ctx, &pb.DeleteUserRequest{Id: id.String()})
+		return err
+	}
+
+	tests := []struct {
+		name     string
+		caller   auth.Identity
+		call     func(h Handler, ctx context.Context) error
+		wantCode codes.Code
+	}{
+		{
+			name:     "test_api_key_cannot_change_password",
+			caller:   apiKey,
+			call:     update(&pb.UpdateUserRequest{Id: id.String(), Password: "stolen"}),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "test_api_key_cannot_change_roles",
+			caller:   apiKey,
+			call:     update(&pb.UpdateUserRequest{Id: id.String(), Roles: []string{database.RoleUser}}),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "test_api_key_cannot_delete_user",
+			caller:   apiKey,
+			call:     deleteUser,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "test_api_key_can_change_username",
+			caller:   apiKey,
+			call:     update(&pb.UpdateUserRequest{Id: id.String(), Username: "renamed"}),
+			wantCode: codes.OK,
+		},
+		{
+			name:     "test_token_can_change_password",
+			caller:   token,
+			call:     update(&pb.UpdateUserRequest{Id: id.String(), Password: "new"}),
+			wantCode: codes.OK,
+		},
+		{
+			name:     "test_token_can_delete_user",
+			caller:   token,
+			call:     deleteUser,
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &fakeUsersRepository{}
+			tokens := &fakeTokensRepository{}
+			h := New(users, tokens, nil, fakeHasher{}, nil, time.Hour, time.Second)
+
+			err := tt.call(*h, auth.WithIdentity(context.Background(), tt.caller))
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (err = %v)", code, tt.wantCode, err)
+			}
+
+			if tt.wantCode != codes.OK && (len(users.updated) != 0 || len(users.deleted) != 0) {
+				t.Errorf("updated = %v, deleted = %v, want the account left as is", users.updated, users.deleted)
+			}
+		})
+	}
+}
+
+func TestHandlerAuthenticateLegacyPassword(t *testing.T) {
+	hasher, err := password.New(password.Config{Algorithm: password.AlgorithmBcrypt, BcryptCost: 4})
+	if err != nil {
+		t.Fatalf("password.New() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+		rehashed bool
+	}{
+		{
+			name:     "test_legacy_password_is_accepted_and_rehashed",
+			password: "secret",
+			rehashed: true,
+		},
+		{
+			name:     "test_wrong_password_is_rejected",
+			password: "wrong",
+			wantErr:  password.ErrMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &fakeUsersRepository{
+				user: database.User{ID: uuid.New(), Username: "legacy", Password: "secret"},
+			}
+			h := New(users, &fakeTokensRepository{}, nil, hasher, nil, time.Hour, time.Second)
+
+			_, err := h.Authenticate(context.Background(), "legacy", tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !tt.rehashed {
+				if len(users.passwords) != 0 {
+					t.Errorf("passwords = %v, want the stored one left as is", users.passwords)
+				}
+				return
+			}
+
+			if len(users.passwords) != 1 {
+				t.Fatalf("passwords = %v, want the password rehashed once", users.passwords)
+			}
+			if algorithm, _ := password.Algorithm(users.user.Password); algorithm != password.AlgorithmBcrypt {
+				t.Errorf("stored password = %q, want a bcrypt hash", users.user.Password)
+			}
+
+			// the next login goes through the hash
+			if _, err := h.Authenticate(context.Background(), "legacy", tt.password); err != nil {
+				t.Errorf("second Authenticate() error = %v", err)
+			}
+			if len(users.passwords) != 1 {
+				t.Errorf("passwords = %v, want no rehash of an up to date hash", users.passwords)
+			}
+		})
+	}
+}
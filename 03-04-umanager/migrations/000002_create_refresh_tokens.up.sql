@@ -0,0 +1,33 @@
+BEGIN;
+
+    CREATE TABLE
+    IF NOT EXISTS refresh_tokens
+    (
+    id          UUID NOT NULL,
+    user_id     UUID NOT NULL,
+    family_id   UUID NOT NULL,
+    token_hash  TEXT NOT NULL,
+    expires_at  TIMESTAMP
+    WITH TIME ZONE NOT NULL,
+    revoked_at  TIMESTAMP
+    WITH TIME ZONE,
+    replaced_by UUID,
+    created_at  TIMESTAMP
+    WITH TIME ZONE DEFAULT NOW
+    (),
+
+    CONSTRAINT pk_refresh_tokens_idx PRIMARY KEY
+    (id),
+    CONSTRAINT refresh_tokens_token_hash_uniq_idx UNIQUE
+    (token_hash),
+    CONSTRAINT fk_refresh_tokens_user_id FOREIGN KEY
+    (user_id) REFERENCES users
+    (id) ON DELETE CASCADE
+);
+
+    CREATE INDEX
+    IF NOT EXISTS refresh_tokens_family_id_idx
+    ON refresh_tokens
+    (family_id);
+
+END;
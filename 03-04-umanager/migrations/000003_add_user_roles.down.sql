@@ -0,0 +1,7 @@
+BEGIN;
+
+    ALTER TABLE users DROP COLUMN IF EXISTS roles;
+
+    DROP TABLE IF EXISTS roles;
+
+END;
@@ -0,0 +1,25 @@
+BEGIN;
+
+    CREATE TABLE
+    IF NOT EXISTS roles
+    (
+    name        TEXT NOT NULL,
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+
+    CONSTRAINT pk_roles_idx PRIMARY KEY
+    (name)
+);
+
+    INSERT INTO roles
+    (name, permissions)
+    VALUES
+    ('user', '{users:read,users:write,links:read,links:write}'),
+    ('admin', '{users:read,users:write,users:manage,links:read,links:write,links:manage}')
+    ON CONFLICT
+    (name) DO NOTHING;
+
+    ALTER TABLE users
+    ADD COLUMN
+    IF NOT EXISTS roles TEXT[] NOT NULL DEFAULT '{user}';
+
+END;
@@ -0,0 +1,34 @@
+BEGIN;
+
+    CREATE TABLE
+    IF NOT EXISTS api_keys
+    (
+    id           UUID NOT NULL,
+    user_id      UUID NOT NULL,
+    name         TEXT NOT NULL,
+    prefix       TEXT NOT NULL,
+    key_hash     TEXT NOT NULL,
+    scopes       TEXT[] NOT NULL DEFAULT '{}',
+    expires_at   TIMESTAMP
+    WITH TIME ZONE,
+    last_used_at TIMESTAMP
+    WITH TIME ZONE,
+    created_at   TIMESTAMP
+    WITH TIME ZONE DEFAULT NOW
+    (),
+
+    CONSTRAINT pk_api_keys_idx PRIMARY KEY
+    (id),
+    CONSTRAINT api_keys_key_hash_uniq_idx UNIQUE
+    (key_hash),
+    CONSTRAINT fk_api_keys_user_id FOREIGN KEY
+    (user_id) REFERENCES users
+    (id) ON DELETE CASCADE
+);
+
+    CREATE INDEX
+    IF NOT EXISTS api_keys_user_id_idx
+    ON api_keys
+    (user_id);
+
+END;
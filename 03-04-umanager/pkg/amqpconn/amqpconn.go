@@ -0,0 +1,317 @@
+// Package amqpconn keeps a RabbitMQ connection alive. When the connection is
+// lost, the Manager reconnects with backoff, declares the topology again and
+// reopens its channels, restoring their QoS and consumers, so that the users
+// of the channels do not notice the outage beyond the failed calls.
+package amqpconn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ErrDisconnected is returned by the channel methods while the connection or
+// the channel is down.
+var ErrDisconnected = errors.New("amqp connection is down")
+
+// Declarer is the part of a channel that declares the topology.
+type Declarer interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+}
+
+// connection and channel are the parts of *amqp.Connection and *amqp.Channel
+// the Manager relies on.
+type connection interface {
+	Channel() (channel, error)
+	NotifyClose(receiver chan *amqp.Error) chan *amqp.Error
+	IsClosed() bool
+	Close() error
+}
+
+type channel interface {
+	Declarer
+	Confirm(noWait bool) error
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (
+		<-chan amqp.Delivery,
+		error,
+	)
+	Cancel(consumer string, noWait bool) error
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	PublishWithDeferredConfirmWithContext(
+		ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing,
+	) (*amqp.DeferredConfirmation, error)
+	QueueDeclarePassive(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	NotifyClose(receiver chan *amqp.Error) chan *amqp.Error
+	IsClosed() bool
+	Close() error
+}
+
+type amqpConnection struct {
+	*amqp.Connection
+}
+
+func (c amqpConnection) Channel() (channel, error) {
+	ch, err := c.Connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+func dialAMQP(url string) (connection, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return amqpConnection{Connection: conn}, nil
+}
+
+type Config struct {
+	URL string
+	// MinBackoff and MaxBackoff bound the delay between the reconnection
+	// attempts, which doubles after every failed one.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Manager owns the connection and the channels opened through it.
+type Manager struct {
+	cfg  Config
+	dial func(url string) (connection, error)
+
+	mu       sync.Mutex
+	conn     connection
+	channels []*Channel
+	// topology declares the exchanges and queues on every new connection.
+	topology []func(ch Declarer) error
+	closed   bool
+	done     chan struct{}
+}
+
+// Dial connects to the broker. It fails if the broker is unavailable at the
+// start; the later outages are recovered.
+func Dial(cfg Config) (*Manager, error) {
+	return dial(cfg, dialAMQP)
+}
+
+func dial(cfg Config, dialer func(url string) (connection, error)) (*Manager, error) {
+	m := &Manager{cfg: cfg, dial: dialer, done: make(chan struct{})}
+
+	conn, err := m.connect()
+	if err != nil {
+		return nil, err
+	}
+	m.conn = conn
+
+	go m.watch(conn)
+
+	return m, nil
+}
+
+// Declare declares the exchanges and queues with topology now and after every
+// reconnection, as the broker may have lost them.
+func (m *Manager) Declare(topology func(ch Declarer) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conn == nil {
+		return ErrDisconnected
+	}
+
+	if err := declare(m.conn, topology); err != nil {
+		return err
+	}
+	m.topology = append(m.topology, topology)
+
+	return nil
+}
+
+// Channel opens a channel that is reopened after reconnections.
+func (m *Manager) Channel() (*Channel, error) {
+	return m.channel(false)
+}
+
+// ConfirmChannel opens a channel in confirm mode that is reopened after
+// reconnections.
+func (m *Manager) ConfirmChannel() (*Channel, error) {
+	return m.channel(true)
+}
+
+func (m *Manager) channel(confirm bool) (*Channel, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conn == nil {
+		return nil, ErrDisconnected
+	}
+
+	c := &Channel{m: m, confirm: confirm, consumers: map[string]*consumer{}}
+	if err := c.open(m.conn); err != nil {
+		return nil, err
+	}
+	m.channels = append(m.channels, c)
+
+	return c, nil
+}
+
+// IsConnected reports whether the connection is up.
+func (m *Manager) IsConnected() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.conn != nil && !m.conn.IsClosed()
+}
+
+// Close closes the channels and the connection and stops reconnecting.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	close(m.done)
+	conn, channels := m.conn, m.channels
+	m.mu.Unlock()
+
+	for _, c := range channels {
+		if err := c.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
+			slog.Error("amqp channel Close", slog.Any("err", err))
+		}
+	}
+
+	if conn == nil {
+		return nil
+	}
+
+	return conn.Close()
+}
+
+func (m *Manager) connect() (connection, error) {
+	conn, err := m.dial(m.cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("amqp Dial: %w", err)
+	}
+
+	m.mu.Lock()
+	topology := m.topology
+	m.mu.Unlock()
+
+	for _, t := range topology {
+		if err := declare(conn, t); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func declare(conn connection, topology func(ch Declarer) error) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("amqp Channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := topology(ch); err != nil {
+		return fmt.Errorf("declare topology: %w", err)
+	}
+
+	return nil
+}
+
+// watch reconnects whenever the connection is lost until m is closed.
+func (m *Manager) watch(conn connection) {
+	for conn != nil {
+		amqpErr := <-conn.NotifyClose(make(chan *amqp.Error, 1))
+
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			return
+		}
+		m.conn = nil
+		m.mu.Unlock()
+
+		slog.Error("amqp connection is lost", slog.Any("err", amqpErr))
+		conn = m.reconnect()
+	}
+}
+
+// reconnect dials until it succeeds, then reopens the channels. It returns
+// nil if m is closed meanwhile.
+func (m *Manager) reconnect() connection {
+	backoff := m.cfg.MinBackoff
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-m.done:
+			return nil
+		case <-time.After(backoff):
+		}
+
+		conn, err := m.connect()
+		if err != nil {
+			slog.Warn("amqp reconnect", slog.Int("attempt", attempt), slog.Any("err", err))
+			backoff = min(2*backoff, m.cfg.MaxBackoff)
+			continue
+		}
+
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			_ = conn.Close()
+			return nil
+		}
+		m.conn = conn
+		for _, c := range m.channels {
+			if err := c.open(conn); err != nil {
+				// the channel is reopened with the next connection if this
+				// one is lost as well
+				slog.Error("amqp reopen channel", slog.Any("err", err))
+			}
+		}
+		m.mu.Unlock()
+
+		slog.Info("amqp connection is restored", slog.Int("attempt", attempt))
+		return conn
+	}
+}
+
+// reopen reopens c closed by a channel exception while conn stays up.
+func (m *Manager) reopen(c *Channel, conn connection) {
+	backoff := m.cfg.MinBackoff
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-time.After(backoff):
+		}
+
+		m.mu.Lock()
+		if m.conn != conn {
+			// the connection is lost, so the channel is reopened along with
+			// the new one
+			m.mu.Unlock()
+			return
+		}
+		err := c.open(conn)
+		m.mu.Unlock()
+
+		if err == nil {
+			return
+		}
+		slog.Warn("amqp reopen channel", slog.Any("err", err))
+		backoff = min(2*backoff, m.cfg.MaxBackoff)
+	}
+}
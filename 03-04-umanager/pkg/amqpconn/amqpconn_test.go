@@ -0,0 +1,399 @@
+package amqpconn
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// fakeBroker dials fake connections and fails to while it is down.
+type fakeBroker struct {
+	mu    sync.Mutex
+	down  bool
+	dials []time.Time
+	conns []*fakeConnection
+}
+
+func (b *fakeBroker) dial(string) (connection, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.dials = append(b.dials, time.Now())
+	if b.down {
+		return nil, errors.New("dial tcp: connection refused")
+	}
+
+	conn := &fakeConnection{}
+	b.conns = append(b.conns, conn)
+
+	return conn, nil
+}
+
+func (b *fakeBroker) setDown(down bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.down = down
+}
+
+func (b *fakeBroker) dialTimes() []time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]time.Time(nil), b.dials...)
+}
+
+// conn returns the last dialed connection.
+func (b *fakeBroker) conn() *fakeConnection {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.conns[len(b.conns)-1]
+}
+
+type fakeConnection struct {
+	mu       sync.Mutex
+	closed   bool
+	notify   []chan *amqp.Error
+	channels []*fakeChannel
+}
+
+func (c *fakeConnection) Channel() (channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, amqp.ErrClosed
+	}
+
+	ch := &fakeChannel{deliveries: map[string]chan amqp.Delivery{}}
+	c.channels = append(c.channels, ch)
+
+	return ch, nil
+}
+
+func (c *fakeConnection) NotifyClose(receiver chan *amqp.Error) chan *amqp.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		close(receiver)
+		return receiver
+	}
+	c.notify = append(c.notify, receiver)
+
+	return receiver
+}
+
+func (c *fakeConnection) IsClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
+
+func (c *fakeConnection) Close() error {
+	c.shutdown(nil)
+	return nil
+}
+
+// shutdown closes the connection along with its channels, as the broker does
+// when the connection is lost with err.
+func (c *fakeConnection) shutdown(err *amqp.Error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	notify, channels := c.notify, c.channels
+	c.mu.Unlock()
+
+	for _, n := range notify {
+		if err != nil {
+			n <- err
+		}
+		close(n)
+	}
+	for _, ch := range channels {
+		ch.shutdown(err)
+	}
+}
+
+// consumer returns the last opened channel consuming with tag.
+func (c *fakeConnection) consumer(tag string) *fakeChannel {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := len(c.channels) - 1; i >= 0; i-- {
+		if c.channels[i].delivery(tag) != nil {
+			return c.channels[i]
+		}
+	}
+
+	return nil
+}
+
+type fakeChannel struct {
+	mu         sync.Mutex
+	closed     bool
+	confirm    bool
+	prefetch   int
+	notify     []chan *amqp.Error
+	deliveries map[string]chan amqp.Delivery
+	published  []amqp.Publishing
+}
+
+func (c *fakeChannel) ExchangeDeclare(string, string, bool, bool, bool, bool, amqp.Table) error {
+	return nil
+}
+
+func (c *fakeChannel) QueueDeclare(name string, _, _, _, _ bool, _ amqp.Table) (amqp.Queue, error) {
+	return amqp.Queue{Name: name}, nil
+}
+
+func (c *fakeChannel) QueueBind(string, string, string, bool, amqp.Table) error {
+	return nil
+}
+
+func (c *fakeChannel) QueueDeclarePassive(name string, _, _, _, _ bool, _ amqp.Table) (amqp.Queue, error) {
+	return amqp.Queue{Name: name}, nil
+}
+
+func (c *fakeChannel) Confirm(bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.confirm = true
+	return nil
+}
+
+func (c *fakeChannel) Qos(prefetchCount, _ int, _ bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prefetch = prefetchCount
+	return nil
+}
+
+func (c *fakeChannel) Consume(_, consumer string, _, _, _, _ bool, _ amqp.Table) (<-chan amqp.Delivery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, amqp.ErrClosed
+	}
+
+	deliveries := make(chan amqp.Delivery)
+	c.deliveries[consumer] = deliveries
+
+	return deliveries, nil
+}
+
+func (c *fakeChannel) Cancel(consumer string, _ bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if d, ok := c.deliveries[consumer]; ok {
+		close(d)
+		delete(c.deliveries, consumer)
+	}
+
+	return nil
+}
+
+func (c *fakeChannel) PublishWithContext(_ context.Context, _, _ string, _, _ bool, msg amqp.Publishing) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return amqp.ErrClosed
+	}
+	c.published = append(c.published, msg)
+
+	return nil
+}
+
+func (c *fakeChannel) PublishWithDeferredConfirmWithContext(
+	ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing,
+) (*amqp.DeferredConfirmation, error) {
+	return nil, c.PublishWithContext(ctx, exchange, key, mandatory, immediate, msg)
+}
+
+func (c *fakeChannel) NotifyClose(receiver chan *amqp.Error) chan *amqp.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		close(receiver)
+		return receiver
+	}
+	c.notify = append(c.notify, receiver)
+
+	return receiver
+}
+
+func (c *fakeChannel) IsClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
+
+func (c *fakeChannel) Close() error {
+	c.shutdown(nil)
+	return nil
+}
+
+// shutdown closes the channel and its deliveries, as the broker does on a
+// channel exception err.
+func (c *fakeChannel) shutdown(err *amqp.Error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	notify, deliveries := c.notify, c.deliveries
+	c.deliveries = map[string]chan amqp.Delivery{}
+	c.mu.Unlock()
+
+	for _, d := range deliveries {
+		close(d)
+	}
+	for _, n := range notify {
+		if err != nil {
+			n <- err
+		}
+		close(n)
+	}
+}
+
+func (c *fakeChannel) delivery(tag string) chan amqp.Delivery {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.deliveries[tag]
+}
+
+func (c *fakeChannel) state() (confirm bool, prefetch int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.confirm, c.prefetch
+}
+
+// eventually fails t unless cond becomes true within a second.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func testConfig() Config {
+	return Config{MinBackoff: 5 * time.Millisecond, MaxBackoff: 20 * time.Millisecond}
+}
+
+func TestManagerReconnectBackoff(t *testing.T) {
+	broker := &fakeBroker{}
+	m, err := dial(testConfig(), broker.dial)
+	if err != nil {
+		t.Fatalf("dial() error = %v", err)
+	}
+	defer m.Close()
+
+	broker.setDown(true)
+	broker.conn().shutdown(&amqp.Error{Code: amqp.ConnectionForced, Reason: "broker restart"})
+
+	// the dial at the start and the failed reconnection attempts
+	const failed = 8
+	eventually(t, "the reconnection attempts", func() bool {
+		return len(broker.dialTimes()) >= 1+failed
+	})
+	if m.IsConnected() {
+		t.Error("IsConnected() = true while the broker is down")
+	}
+
+	broker.setDown(false)
+	eventually(t, "the reconnection", m.IsConnected)
+
+	// the delay doubles from MinBackoff after every failed attempt and stops
+	// growing at MaxBackoff
+	dials := broker.dialTimes()[1:]
+	want := []time.Duration{10, 20, 20, 20, 20, 20, 20}
+	for i, w := range want {
+		if gap := dials[i+1].Sub(dials[i]); gap < w*time.Millisecond {
+			t.Errorf("delay before attempt %d = %s, want at least %dms", i+2, gap, w)
+		}
+	}
+	// without the cap the attempts would take 10+20+...+640ms
+	if total := dials[failed-1].Sub(dials[0]); total > 600*time.Millisecond {
+		t.Errorf("attempts took %s, want the backoff capped at %s", total, testConfig().MaxBackoff)
+	}
+}
+
+func TestManagerDialFailure(t *testing.T) {
+	broker := &fakeBroker{down: true}
+
+	if _, err := dial(testConfig(), broker.dial); err == nil {
+		t.Fatal("dial() error = nil, want the broker to be unavailable at the start")
+	}
+}
+
+func TestManagerCloseStopsGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	broker := &fakeBroker{}
+	m, err := dial(testConfig(), broker.dial)
+	if err != nil {
+		t.Fatalf("dial() error = %v", err)
+	}
+	ch, err := m.Channel()
+	if err != nil {
+		t.Fatalf("Channel() error = %v", err)
+	}
+	deliveries, err := ch.Consume("links", "worker", false, false, false, false, nil)
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := m.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+
+	select {
+	case _, ok := <-deliveries:
+		if ok {
+			t.Error("unexpected delivery after Close")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("deliveries are not closed by Close")
+	}
+	if !broker.conn().IsClosed() {
+		t.Error("connection is not closed")
+	}
+	if !ch.IsClosed() {
+		t.Error("channel is not closed")
+	}
+	if _, err := m.Channel(); err == nil {
+		t.Error("Channel() after Close error = nil")
+	}
+
+	eventually(t, "the goroutines to stop", func() bool {
+		return runtime.NumGoroutine() <= before
+	})
+}
@@ -0,0 +1,292 @@
+package amqpconn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Channel is a channel of a Manager. Its methods fail with ErrDisconnected
+// while the connection is down instead of blocking, so publishers can keep
+// the messages and try again later.
+type Channel struct {
+	m       *Manager
+	confirm bool
+
+	mu        sync.Mutex
+	ch        channel
+	qos       *qos
+	consumers map[string]*consumer
+	closed    bool
+}
+
+type qos struct {
+	prefetchCount, prefetchSize int
+	global                      bool
+}
+
+// consumer is resumed on every reopened channel and forwards the deliveries
+// to out, which survives the reconnections.
+type consumer struct {
+	queue                               string
+	autoAck, exclusive, noLocal, noWait bool
+	args                                amqp.Table
+
+	out  chan amqp.Delivery
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// open replaces the underlying channel with a new one of conn.
+func (c *Channel) open(conn connection) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("amqp Channel: %w", err)
+	}
+
+	if err := c.setup(ch); err != nil {
+		_ = ch.Close()
+		return err
+	}
+
+	c.ch = ch
+	go c.watch(conn, ch, ch.NotifyClose(make(chan *amqp.Error, 1)))
+
+	return nil
+}
+
+func (c *Channel) setup(ch channel) error {
+	if c.confirm {
+		if err := ch.Confirm(false); err != nil {
+			return fmt.Errorf("amqp Confirm: %w", err)
+		}
+	}
+
+	if c.qos != nil {
+		if err := ch.Qos(c.qos.prefetchCount, c.qos.prefetchSize, c.qos.global); err != nil {
+			return fmt.Errorf("amqp Qos: %w", err)
+		}
+	}
+
+	for tag, cons := range c.consumers {
+		if err := cons.consume(ch, tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// watch marks the channel down once ch is closed and reopens it if the
+// connection is still up.
+func (c *Channel) watch(conn connection, ch channel, notify chan *amqp.Error) {
+	amqpErr, ok := <-notify
+
+	c.mu.Lock()
+	if c.ch == ch {
+		c.ch = nil
+	}
+	closed := c.closed
+	c.mu.Unlock()
+
+	// a channel closed by Close or along with the connection is handled
+	// elsewhere
+	if !ok || closed || conn.IsClosed() {
+		return
+	}
+
+	slog.Error("amqp channel is closed", slog.Any("err", amqpErr))
+	c.m.reopen(c, conn)
+}
+
+// down reports whether the channel is down, including the time between the
+// channel being closed and watch noticing it. c.mu must be held.
+func (c *Channel) down() bool {
+	return c.ch == nil || c.ch.IsClosed()
+}
+
+func (c *Channel) current() (channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.down() {
+		return nil, ErrDisconnected
+	}
+
+	return c.ch, nil
+}
+
+// Qos sets the prefetch of the channel and keeps it for the reopened ones.
+func (c *Channel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.down() {
+		return ErrDisconnected
+	}
+
+	if err := c.ch.Qos(prefetchCount, prefetchSize, global); err != nil {
+		return err
+	}
+	c.qos = &qos{prefetchCount: prefetchCount, prefetchSize: prefetchSize, global: global}
+
+	return nil
+}
+
+// Consume starts a consumer that is resumed on the reopened channels. The
+// returned channel is closed by Cancel or Close only. The deliveries received
+// before a reconnection cannot be acked anymore and are redelivered.
+func (c *Channel) Consume(
+	queue, consumerTag string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table,
+) (<-chan amqp.Delivery, error) {
+	if consumerTag == "" {
+		return nil, errors.New("amqpconn: consumer tag is required to resume consuming")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.down() {
+		return nil, ErrDisconnected
+	}
+	if _, ok := c.consumers[consumerTag]; ok {
+		return nil, fmt.Errorf("amqpconn: consumer %s already exists", consumerTag)
+	}
+
+	cons := &consumer{
+		queue:     queue,
+		autoAck:   autoAck,
+		exclusive: exclusive,
+		noLocal:   noLocal,
+		noWait:    noWait,
+		args:      args,
+		out:       make(chan amqp.Delivery),
+		stop:      make(chan struct{}),
+	}
+	if err := cons.consume(c.ch, consumerTag); err != nil {
+		return nil, err
+	}
+	c.consumers[consumerTag] = cons
+
+	return cons.out, nil
+}
+
+func (cons *consumer) consume(ch channel, tag string) error {
+	deliveries, err := ch.Consume(cons.queue, tag, cons.autoAck, cons.exclusive, cons.noLocal, cons.noWait, cons.args)
+	if err != nil {
+		return fmt.Errorf("amqp Consume: %w", err)
+	}
+
+	cons.wg.Add(1)
+	go func() {
+		defer cons.wg.Done()
+		for d := range deliveries {
+			select {
+			case cons.out <- d:
+			case <-cons.stop:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// finish closes out once the deliveries of the current channel are no
+// longer forwarded.
+func (cons *consumer) finish() {
+	close(cons.stop)
+	go func() {
+		cons.wg.Wait()
+		close(cons.out)
+	}()
+}
+
+// Cancel stops the consumer, so that it is not resumed anymore.
+func (c *Channel) Cancel(consumerTag string, noWait bool) error {
+	c.mu.Lock()
+	cons, ok := c.consumers[consumerTag]
+	delete(c.consumers, consumerTag)
+	ch := c.ch
+	c.mu.Unlock()
+
+	if ok {
+		cons.finish()
+	}
+	if ch == nil {
+		// the broker dropped the consumer along with the channel
+		return nil
+	}
+
+	return ch.Cancel(consumerTag, noWait)
+}
+
+func (c *Channel) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	ch, err := c.current()
+	if err != nil {
+		return err
+	}
+
+	return ch.PublishWithContext(context.Background(), exchange, key, mandatory, immediate, msg)
+}
+
+func (c *Channel) PublishWithDeferredConfirmWithContext(
+	ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing,
+) (*amqp.DeferredConfirmation, error) {
+	ch, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+
+	return ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, mandatory, immediate, msg)
+}
+
+func (c *Channel) QueueDeclarePassive(
+	name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table,
+) (amqp.Queue, error) {
+	ch, err := c.current()
+	if err != nil {
+		return amqp.Queue{}, err
+	}
+
+	return ch.QueueDeclarePassive(name, durable, autoDelete, exclusive, noWait, args)
+}
+
+// IsClosed reports whether the channel is down or closed.
+func (c *Channel) IsClosed() bool {
+	ch, err := c.current()
+	return err != nil || ch.IsClosed()
+}
+
+// Close closes the channel and its consumers. It is not reopened anymore.
+func (c *Channel) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	consumers, ch := c.consumers, c.ch
+	c.consumers, c.ch = nil, nil
+	c.mu.Unlock()
+
+	for _, cons := range consumers {
+		cons.finish()
+	}
+	if ch == nil {
+		return nil
+	}
+
+	return ch.Close()
+}
@@ -0,0 +1,171 @@
+package amqpconn
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// receive fails t unless a delivery with id comes from deliveries.
+func receive(t *testing.T, deliveries <-chan amqp.Delivery, id string) {
+	t.Helper()
+
+	select {
+	case d, ok := <-deliveries:
+		if !ok {
+			t.Fatal("deliveries are closed")
+		}
+		if d.MessageId != id {
+			t.Fatalf("delivery = %q, want %q", d.MessageId, id)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("delivery %q is not forwarded", id)
+	}
+}
+
+func TestChannelReopen(t *testing.T) {
+	broker := &fakeBroker{}
+	m, err := dial(testConfig(), broker.dial)
+	if err != nil {
+		t.Fatalf("dial() error = %v", err)
+	}
+	defer m.Close()
+
+	ch, err := m.Channel()
+	if err != nil {
+		t.Fatalf("Channel() error = %v", err)
+	}
+	if err := ch.Qos(4, 0, false); err != nil {
+		t.Fatalf("Qos() error = %v", err)
+	}
+	deliveries, err := ch.Consume("links", "worker", false, false, false, false, nil)
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+
+	conn := broker.conn()
+	closed := conn.consumer("worker")
+	closed.shutdown(&amqp.Error{Code: amqp.PreconditionFailed, Reason: "unknown delivery tag"})
+
+	eventually(t, "the channel to be reopened", func() bool {
+		reopened := conn.consumer("worker")
+		return reopened != nil && reopened != closed && !ch.IsClosed()
+	})
+	if !m.IsConnected() {
+		t.Error("connection is lost along with the channel")
+	}
+
+	reopened := conn.consumer("worker")
+	if _, prefetch := reopened.state(); prefetch != 4 {
+		t.Errorf("prefetch = %d, want the QoS restored", prefetch)
+	}
+
+	reopened.delivery("worker") <- amqp.Delivery{MessageId: "after-reopen"}
+	receive(t, deliveries, "after-reopen")
+}
+
+func TestChannelReconnect(t *testing.T) {
+	broker := &fakeBroker{}
+	m, err := dial(testConfig(), broker.dial)
+	if err != nil {
+		t.Fatalf("dial() error = %v", err)
+	}
+	defer m.Close()
+
+	ch, err := m.ConfirmChannel()
+	if err != nil {
+		t.Fatalf("ConfirmChannel() error = %v", err)
+	}
+	if err := ch.Qos(2, 0, false); err != nil {
+		t.Fatalf("Qos() error = %v", err)
+	}
+	deliveries, err := ch.Consume("links", "worker", false, false, false, false, nil)
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+
+	broker.conn().consumer("worker").delivery("worker") <- amqp.Delivery{MessageId: "before-outage"}
+	receive(t, deliveries, "before-outage")
+
+	broker.setDown(true)
+	broker.conn().shutdown(&amqp.Error{Code: amqp.ConnectionForced, Reason: "broker restart"})
+	eventually(t, "the channel to be down", ch.IsClosed)
+
+	// the calls fail fast during the outage instead of blocking
+	msg := amqp.Publishing{MessageId: "during-outage"}
+	if err := ch.Publish("links", "link.created", false, false, msg); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("Publish() error = %v, want %v", err, ErrDisconnected)
+	}
+	_, err = ch.PublishWithDeferredConfirmWithContext(context.Background(), "links", "link.created", false, false, msg)
+	if !errors.Is(err, ErrDisconnected) {
+		t.Errorf("PublishWithDeferredConfirmWithContext() error = %v, want %v", err, ErrDisconnected)
+	}
+	if _, err := ch.QueueDeclarePassive("links", true, false, false, false, nil); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("QueueDeclarePassive() error = %v, want %v", err, ErrDisconnected)
+	}
+	if _, err := ch.Consume("links", "other", false, false, false, false, nil); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("Consume() error = %v, want %v", err, ErrDisconnected)
+	}
+
+	broker.setDown(false)
+	eventually(t, "the channel to be reopened", func() bool {
+		return m.IsConnected() && !ch.IsClosed()
+	})
+
+	reopened := broker.conn().consumer("worker")
+	if reopened == nil {
+		t.Fatal("consumer is not resumed on the new connection")
+	}
+	if confirm, prefetch := reopened.state(); !confirm || prefetch != 2 {
+		t.Errorf("confirm = %v, prefetch = %d, want the confirm mode and the QoS restored", confirm, prefetch)
+	}
+
+	reopened.delivery("worker") <- amqp.Delivery{MessageId: "after-outage"}
+	receive(t, deliveries, "after-outage")
+
+	if err := ch.Publish("links", "link.created", false, false, amqp.Publishing{}); err != nil {
+		t.Errorf("Publish() after reconnection error = %v", err)
+	}
+}
+
+func TestChannelCancel(t *testing.T) {
+	broker := &fakeBroker{}
+	m, err := dial(testConfig(), broker.dial)
+	if err != nil {
+		t.Fatalf("dial() error = %v", err)
+	}
+	defer m.Close()
+
+	ch, err := m.Channel()
+	if err != nil {
+		t.Fatalf("Channel() error = %v", err)
+	}
+	deliveries, err := ch.Consume("links", "worker", false, false, false, false, nil)
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	if err := ch.Cancel("worker", false); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+
+	select {
+	case _, ok := <-deliveries:
+		if ok {
+			t.Error("unexpected delivery after Cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("deliveries are not closed by Cancel")
+	}
+
+	// a canceled consumer is not resumed
+	broker.conn().shutdown(&amqp.Error{Code: amqp.ConnectionForced, Reason: "broker restart"})
+	eventually(t, "the reconnection", func() bool {
+		return len(broker.dialTimes()) == 2 && m.IsConnected() && !ch.IsClosed()
+	})
+	if broker.conn().consumer("worker") != nil {
+		t.Error("canceled consumer is resumed")
+	}
+}
@@ -15,24 +15,128 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ApiKeyCreateScopes.
+const (
+	Full      ApiKeyCreateScopes = "full"
+	LinksRead ApiKeyCreateScopes = "links:read"
+)
+
+// Defines values for EnrichmentState.
+const (
+	Blocked EnrichmentState = "blocked"
+	Failed  EnrichmentState = "failed"
+	Ok      EnrichmentState = "ok"
+	Pending EnrichmentState = "pending"
+	Skipped EnrichmentState = "skipped"
 )
 
 // Defines values for ErrorCode.
 const (
 	BadRequest          ErrorCode = "badRequest"
 	Conflict            ErrorCode = "conflict"
+	Forbidden           ErrorCode = "forbidden"
+	GatewayTimeout      ErrorCode = "gatewayTimeout"
 	InternalServerError ErrorCode = "internalServerError"
 	NotFound            ErrorCode = "notFound"
+	ServiceUnavailable  ErrorCode = "serviceUnavailable"
+	TooManyRequests     ErrorCode = "tooManyRequests"
+	Unauthorized        ErrorCode = "unauthorized"
+)
+
+// Defines values for GetLinksParamsMatch.
+const (
+	All GetLinksParamsMatch = "all"
+	Any GetLinksParamsMatch = "any"
+)
+
+// Defines values for GetLinksParamsSort.
+const (
+	GetLinksParamsSortCreatedAt      GetLinksParamsSort = "created_at"
+	GetLinksParamsSortMinusCreatedAt GetLinksParamsSort = "-created_at"
+)
+
+// Defines values for GetUsersParamsSort.
+const (
+	GetUsersParamsSortCreatedAt      GetUsersParamsSort = "created_at"
+	GetUsersParamsSortMinusCreatedAt GetUsersParamsSort = "-created_at"
 )
 
+// ApiKey defines model for ApiKey.
+type ApiKey struct {
+	CreatedAt  string   `json:"created_at"`
+	ExpiresAt  *string  `json:"expires_at,omitempty"`
+	Id         string   `json:"id"`
+	LastUsedAt *string  `json:"last_used_at,omitempty"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+}
+
+// ApiKeyCreate defines model for ApiKeyCreate.
+type ApiKeyCreate struct {
+	// ExpiresAt Время истечения ключа, без него ключ бессрочный
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
+
+	// Scopes links:read - только чтение ссылок, full - все права пользователя (по умолчанию)
+	Scopes *[]ApiKeyCreateScopes `json:"scopes,omitempty"`
+}
+
+// ApiKeyCreateScopes defines model for ApiKeyCreate.Scopes.
+type ApiKeyCreateScopes string
+
+// ApiKeyCreated defines model for ApiKeyCreated.
+type ApiKeyCreated struct {
+	ApiKey ApiKey `json:"api_key"`
+	Key    string `json:"key"`
+}
+
+// Enrichment Состояние получения метаданных страницы ссылки
+type Enrichment struct {
+	// Attempts Попытки с последней постановки в очередь
+	Attempts int32 `json:"attempts"`
+
+	// Broken Ссылка не открывается несколько проверок подряд
+	Broken bool `json:"broken"`
+
+	// ConsecutiveFailures Неудачные проверки подряд
+	ConsecutiveFailures int32 `json:"consecutive_failures"`
+
+	// HttpStatus Статус последнего ответа
+	HttpStatus    *int32     `json:"http_status,omitempty"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	LastError     *string    `json:"last_error,omitempty"`
+
+	// RedirectUrl Куда перенаправляет URL ссылки
+	RedirectUrl *string `json:"redirect_url,omitempty"`
+
+	// State skipped - страницу нельзя получить или это не HTML, blocked - получать страницу запрещает robots.txt сайта
+	State EnrichmentState `json:"state"`
+}
+
+// EnrichmentState skipped - страницу нельзя получить или это не HTML, blocked - получать страницу запрещает robots.txt сайта
+type EnrichmentState string
+
 // Error defines model for Error.
 type Error struct {
 	Code    ErrorCode `json:"code"`
 	Message *string   `json:"message,omitempty"`
+
+	// RequestId Идентификатор запроса, совпадает с заголовком X-Request-Id
+	RequestId *string `json:"request_id,omitempty"`
 }
 
 // ErrorCode defines model for Error.Code.
@@ -40,42 +144,157 @@ type ErrorCode string
 
 // Link defines model for Link.
 type Link struct {
-	CreatedAt string   `json:"created_at"`
-	Id        string   `json:"id"`
-	Images    []string `json:"images"`
-	Tags      []string `json:"tags"`
-	Title     string   `json:"title"`
-	UpdatedAt string   `json:"updated_at"`
-	Url       string   `json:"url"`
-	UserId    string   `json:"user_id"`
+	CreatedAt string `json:"created_at"`
+
+	// Enrichment Состояние получения метаданных страницы ссылки
+	Enrichment Enrichment `json:"enrichment"`
+	Id         string     `json:"id"`
+	Images     []string   `json:"images"`
+	Tags       []string   `json:"tags"`
+	Title      string     `json:"title"`
+	UpdatedAt  string     `json:"updated_at"`
+	Url        string     `json:"url"`
+	UserId     string     `json:"user_id"`
 }
 
 // LinkCreate defines model for LinkCreate.
 type LinkCreate struct {
-	Id     string   `json:"id"`
-	Images []string `json:"images"`
-	Tags   []string `json:"tags"`
-	Title  string   `json:"title"`
-	Url    string   `json:"url"`
-	UserId string   `json:"user_id"`
+	// Id Только при обновлении, должен совпадать с ID в пути
+	Id     *string   `json:"id,omitempty"`
+	Images *[]string `json:"images,omitempty"`
+	Tags   *[]string `json:"tags,omitempty"`
+	Title  *string   `json:"title,omitempty"`
+	Url    string    `json:"url"`
+}
+
+// LinkPage defines model for LinkPage.
+type LinkPage struct {
+	Links []Link `json:"links"`
+
+	// NextCursor Курсор следующей страницы, отсутствует на последней странице
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+// RefreshRequest defines model for RefreshRequest.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenPair defines model for TokenPair.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
 }
 
 // User defines model for User.
 type User struct {
-	CreatedAt string `json:"created_at"`
-	Id        string `json:"id"`
-	Password  string `json:"password"`
-	UpdatedAt string `json:"updated_at"`
-	Username  string `json:"username"`
+	CreatedAt string   `json:"created_at"`
+	Id        string   `json:"id"`
+	Roles     []string `json:"roles"`
+	UpdatedAt string   `json:"updated_at"`
+	Username  string   `json:"username"`
 }
 
 // UserCreate defines model for UserCreate.
 type UserCreate struct {
-	Id       string `json:"id"`
 	Password string `json:"password"`
 	Username string `json:"username"`
 }
 
+// UserPage defines model for UserPage.
+type UserPage struct {
+	// NextCursor Курсор следующей страницы, отсутствует на последней странице
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Users      []User  `json:"users"`
+}
+
+// UserUpdate defines model for UserUpdate.
+type UserUpdate struct {
+	// Id Должен совпадать с ID в пути
+	Id       *openapi_types.UUID `json:"id,omitempty"`
+	Password *string             `json:"password,omitempty"`
+
+	// Roles Роли пользователя, может менять только администратор
+	Roles    *[]string `json:"roles,omitempty"`
+	Username *string   `json:"username,omitempty"`
+}
+
+// GetLinksParams defines parameters for GetLinks.
+type GetLinksParams struct {
+	// UserId Владелец ссылок
+	UserId *string `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// Tag Теги, параметр можно повторять
+	Tag *[]string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Match any - ссылка содержит хотя бы один из тегов, all - все теги
+	Match *GetLinksParamsMatch `form:"match,omitempty" json:"match,omitempty"`
+
+	// Q Полнотекстовый поиск по заголовку и URL без учета регистра
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Broken true - только битые ссылки, false - только рабочие
+	Broken *bool `form:"broken,omitempty" json:"broken,omitempty"`
+
+	// CreatedAfter Созданы не раньше указанного момента
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Созданы раньше указанного момента
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// UpdatedAfter Обновлены не раньше указанного момента
+	UpdatedAfter *time.Time `form:"updated_after,omitempty" json:"updated_after,omitempty"`
+
+	// UpdatedBefore Обновлены раньше указанного момента
+	UpdatedBefore *time.Time `form:"updated_before,omitempty" json:"updated_before,omitempty"`
+
+	// Limit Размер страницы, по умолчанию 50, не больше 500
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor предыдущей страницы
+	Cursor *string             `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Sort   *GetLinksParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// GetLinksParamsMatch defines parameters for GetLinks.
+type GetLinksParamsMatch string
+
+// GetLinksParamsSort defines parameters for GetLinks.
+type GetLinksParamsSort string
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Limit Размер страницы, по умолчанию 50, не больше 500
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor предыдущей страницы
+	Cursor *string             `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Sort   *GetUsersParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// GetUsersParamsSort defines parameters for GetUsers.
+type GetUsersParamsSort string
+
+// PostApiKeysJSONRequestBody defines body for PostApiKeys for application/json ContentType.
+type PostApiKeysJSONRequestBody = ApiKeyCreate
+
+// PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
+type PostAuthLoginJSONRequestBody = LoginRequest
+
+// PostAuthLogoutJSONRequestBody defines body for PostAuthLogout for application/json ContentType.
+type PostAuthLogoutJSONRequestBody = RefreshRequest
+
+// PostAuthRefreshJSONRequestBody defines body for PostAuthRefresh for application/json ContentType.
+type PostAuthRefreshJSONRequestBody = RefreshRequest
+
 // PostLinksJSONRequestBody defines body for PostLinks for application/json ContentType.
 type PostLinksJSONRequestBody = LinkCreate
 
@@ -86,7 +305,7 @@ type PutLinksIdJSONRequestBody = LinkCreate
 type PostUsersJSONRequestBody = UserCreate
 
 // PutUsersIdJSONRequestBody defines body for PutUsersId for application/json ContentType.
-type PutUsersIdJSONRequestBody = UserCreate
+type PutUsersIdJSONRequestBody = UserUpdate
 
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
@@ -161,8 +380,34 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetApiKeys request
+	GetApiKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiKeysWithBody request with any body
+	PostApiKeysWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiKeys(ctx context.Context, body PostApiKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiKeysId request
+	DeleteApiKeysId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthLoginWithBody request with any body
+	PostAuthLoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthLogin(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthLogoutWithBody request with any body
+	PostAuthLogoutWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthLogout(ctx context.Context, body PostAuthLogoutJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthRefreshWithBody request with any body
+	PostAuthRefreshWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthRefresh(ctx context.Context, body PostAuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetLinks request
-	GetLinks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetLinks(ctx context.Context, params *GetLinksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// PostLinksWithBody request with any body
 	PostLinksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -183,8 +428,11 @@ type ClientInterface interface {
 
 	PutLinksId(ctx context.Context, id string, body PutLinksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostLinksIdRefresh request
+	PostLinksIdRefresh(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetUsers request
-	GetUsers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetUsers(ctx context.Context, params *GetUsersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// PostUsersWithBody request with any body
 	PostUsersWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -203,8 +451,128 @@ type ClientInterface interface {
 	PutUsersId(ctx context.Context, id string, body PutUsersIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) GetLinks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetLinksRequest(c.Server)
+func (c *Client) GetApiKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiKeysRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiKeysWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiKeysRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiKeys(ctx context.Context, body PostApiKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiKeysRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteApiKeysId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiKeysIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthLoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLoginRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthLogin(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLoginRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthLogoutWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLogoutRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthLogout(ctx context.Context, body PostAuthLogoutJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLogoutRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthRefreshWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthRefreshRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthRefresh(ctx context.Context, body PostAuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthRefreshRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLinks(ctx context.Context, params *GetLinksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLinksRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -299,8 +667,20 @@ func (c *Client) PutLinksId(ctx context.Context, id string, body PutLinksIdJSONR
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetUsers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetUsersRequest(c.Server)
+func (c *Client) PostLinksIdRefresh(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostLinksIdRefreshRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsers(ctx context.Context, params *GetUsersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsersRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -383,8 +763,8 @@ func (c *Client) PutUsersId(ctx context.Context, id string, body PutUsersIdJSONR
 	return c.Client.Do(req)
 }
 
-// NewGetLinksRequest generates requests for GetLinks
-func NewGetLinksRequest(server string) (*http.Request, error) {
+// NewGetApiKeysRequest generates requests for GetApiKeys
+func NewGetApiKeysRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -392,7 +772,7 @@ func NewGetLinksRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/links")
+	operationPath := fmt.Sprintf("/api-keys")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -410,19 +790,19 @@ func NewGetLinksRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPostLinksRequest calls the generic PostLinks builder with application/json body
-func NewPostLinksRequest(server string, body PostLinksJSONRequestBody) (*http.Request, error) {
+// NewPostApiKeysRequest calls the generic PostApiKeys builder with application/json body
+func NewPostApiKeysRequest(server string, body PostApiKeysJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostLinksRequestWithBody(server, "application/json", bodyReader)
+	return NewPostApiKeysRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostLinksRequestWithBody generates requests for PostLinks with any type of body
-func NewPostLinksRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostApiKeysRequestWithBody generates requests for PostApiKeys with any type of body
+func NewPostApiKeysRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -430,7 +810,7 @@ func NewPostLinksRequestWithBody(server string, contentType string, body io.Read
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/links")
+	operationPath := fmt.Sprintf("/api-keys")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -450,13 +830,13 @@ func NewPostLinksRequestWithBody(server string, contentType string, body io.Read
 	return req, nil
 }
 
-// NewGetLinksUserUserIDRequest generates requests for GetLinksUserUserID
-func NewGetLinksUserUserIDRequest(server string, userID string) (*http.Request, error) {
+// NewDeleteApiKeysIdRequest generates requests for DeleteApiKeysId
+func NewDeleteApiKeysIdRequest(server string, id string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userID", runtime.ParamLocationPath, userID)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
 	if err != nil {
 		return nil, err
 	}
@@ -466,7 +846,7 @@ func NewGetLinksUserUserIDRequest(server string, userID string) (*http.Request,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/links/user/%s", pathParam0)
+	operationPath := fmt.Sprintf("/api-keys/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -476,7 +856,7 @@ func NewGetLinksUserUserIDRequest(server string, userID string) (*http.Request,
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -484,23 +864,27 @@ func NewGetLinksUserUserIDRequest(server string, userID string) (*http.Request,
 	return req, nil
 }
 
-// NewDeleteLinksIdRequest generates requests for DeleteLinksId
-func NewDeleteLinksIdRequest(server string, id string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+// NewPostAuthLoginRequest calls the generic PostAuthLogin builder with application/json body
+func NewPostAuthLoginRequest(server string, body PostAuthLoginJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthLoginRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthLoginRequestWithBody generates requests for PostAuthLogin with any type of body
+func NewPostAuthLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/links/%s", pathParam0)
+	operationPath := fmt.Sprintf("/auth/login")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -510,31 +894,37 @@ func NewDeleteLinksIdRequest(server string, id string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewGetLinksIdRequest generates requests for GetLinksId
-func NewGetLinksIdRequest(server string, id string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+// NewPostAuthLogoutRequest calls the generic PostAuthLogout builder with application/json body
+func NewPostAuthLogoutRequest(server string, body PostAuthLogoutJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthLogoutRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthLogoutRequestWithBody generates requests for PostAuthLogout with any type of body
+func NewPostAuthLogoutRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/links/%s", pathParam0)
+	operationPath := fmt.Sprintf("/auth/logout")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -544,42 +934,37 @@ func NewGetLinksIdRequest(server string, id string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewPutLinksIdRequest calls the generic PutLinksId builder with application/json body
-func NewPutLinksIdRequest(server string, id string, body PutLinksIdJSONRequestBody) (*http.Request, error) {
+// NewPostAuthRefreshRequest calls the generic PostAuthRefresh builder with application/json body
+func NewPostAuthRefreshRequest(server string, body PostAuthRefreshJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPutLinksIdRequestWithBody(server, id, "application/json", bodyReader)
+	return NewPostAuthRefreshRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPutLinksIdRequestWithBody generates requests for PutLinksId with any type of body
-func NewPutLinksIdRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostAuthRefreshRequestWithBody generates requests for PostAuthRefresh with any type of body
+func NewPostAuthRefreshRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/links/%s", pathParam0)
+	operationPath := fmt.Sprintf("/auth/refresh")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -589,7 +974,7 @@ func NewPutLinksIdRequestWithBody(server string, id string, contentType string,
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -599,8 +984,8 @@ func NewPutLinksIdRequestWithBody(server string, id string, contentType string,
 	return req, nil
 }
 
-// NewGetUsersRequest generates requests for GetUsers
-func NewGetUsersRequest(server string) (*http.Request, error) {
+// NewGetLinksRequest generates requests for GetLinks
+func NewGetLinksRequest(server string, params *GetLinksParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -608,7 +993,7 @@ func NewGetUsersRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/users")
+	operationPath := fmt.Sprintf("/links")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -618,6 +1003,204 @@ func NewGetUsersRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.UserId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, *params.UserId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tag != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag", runtime.ParamLocationQuery, *params.Tag); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Match != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "match", runtime.ParamLocationQuery, *params.Match); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Broken != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "broken", runtime.ParamLocationQuery, *params.Broken); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_after", runtime.ParamLocationQuery, *params.CreatedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedBefore != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_before", runtime.ParamLocationQuery, *params.CreatedBefore); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.UpdatedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "updated_after", runtime.ParamLocationQuery, *params.UpdatedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.UpdatedBefore != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "updated_before", runtime.ParamLocationQuery, *params.UpdatedBefore); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -626,19 +1209,19 @@ func NewGetUsersRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPostUsersRequest calls the generic PostUsers builder with application/json body
-func NewPostUsersRequest(server string, body PostUsersJSONRequestBody) (*http.Request, error) {
+// NewPostLinksRequest calls the generic PostLinks builder with application/json body
+func NewPostLinksRequest(server string, body PostLinksJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostUsersRequestWithBody(server, "application/json", bodyReader)
+	return NewPostLinksRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostUsersRequestWithBody generates requests for PostUsers with any type of body
-func NewPostUsersRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostLinksRequestWithBody generates requests for PostLinks with any type of body
+func NewPostLinksRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -646,7 +1229,7 @@ func NewPostUsersRequestWithBody(server string, contentType string, body io.Read
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/users")
+	operationPath := fmt.Sprintf("/links")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -666,8 +1249,42 @@ func NewPostUsersRequestWithBody(server string, contentType string, body io.Read
 	return req, nil
 }
 
-// NewDeleteUsersIdRequest generates requests for DeleteUsersId
-func NewDeleteUsersIdRequest(server string, id string) (*http.Request, error) {
+// NewGetLinksUserUserIDRequest generates requests for GetLinksUserUserID
+func NewGetLinksUserUserIDRequest(server string, userID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userID", runtime.ParamLocationPath, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/links/user/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteLinksIdRequest generates requests for DeleteLinksId
+func NewDeleteLinksIdRequest(server string, id string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -682,7 +1299,7 @@ func NewDeleteUsersIdRequest(server string, id string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	operationPath := fmt.Sprintf("/links/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -700,8 +1317,8 @@ func NewDeleteUsersIdRequest(server string, id string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetUsersIdRequest generates requests for GetUsersId
-func NewGetUsersIdRequest(server string, id string) (*http.Request, error) {
+// NewGetLinksIdRequest generates requests for GetLinksId
+func NewGetLinksIdRequest(server string, id string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -716,7 +1333,7 @@ func NewGetUsersIdRequest(server string, id string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	operationPath := fmt.Sprintf("/links/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -734,19 +1351,19 @@ func NewGetUsersIdRequest(server string, id string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPutUsersIdRequest calls the generic PutUsersId builder with application/json body
-func NewPutUsersIdRequest(server string, id string, body PutUsersIdJSONRequestBody) (*http.Request, error) {
+// NewPutLinksIdRequest calls the generic PutLinksId builder with application/json body
+func NewPutLinksIdRequest(server string, id string, body PutLinksIdJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPutUsersIdRequestWithBody(server, id, "application/json", bodyReader)
+	return NewPutLinksIdRequestWithBody(server, id, "application/json", bodyReader)
 }
 
-// NewPutUsersIdRequestWithBody generates requests for PutUsersId with any type of body
-func NewPutUsersIdRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPutLinksIdRequestWithBody generates requests for PutLinksId with any type of body
+func NewPutLinksIdRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -761,7 +1378,7 @@ func NewPutUsersIdRequestWithBody(server string, id string, contentType string,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	operationPath := fmt.Sprintf("/links/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -781,336 +1398,452 @@ func NewPutUsersIdRequestWithBody(server string, id string, contentType string,
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewPostLinksIdRefreshRequest generates requests for PostLinksIdRefresh
+func NewPostLinksIdRefreshRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	operationPath := fmt.Sprintf("/links/%s/refresh", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-}
-
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetLinksWithResponse request
-	GetLinksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLinksResponse, error)
 
-	// PostLinksWithBodyWithResponse request with any body
-	PostLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLinksResponse, error)
+	return req, nil
+}
 
-	PostLinksWithResponse(ctx context.Context, body PostLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostLinksResponse, error)
+// NewGetUsersRequest generates requests for GetUsers
+func NewGetUsersRequest(server string, params *GetUsersParams) (*http.Request, error) {
+	var err error
 
-	// GetLinksUserUserIDWithResponse request
-	GetLinksUserUserIDWithResponse(ctx context.Context, userID string, reqEditors ...RequestEditorFn) (*GetLinksUserUserIDResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	// DeleteLinksIdWithResponse request
-	DeleteLinksIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteLinksIdResponse, error)
+	operationPath := fmt.Sprintf("/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// GetLinksIdWithResponse request
-	GetLinksIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetLinksIdResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// PutLinksIdWithBodyWithResponse request with any body
-	PutLinksIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutLinksIdResponse, error)
+	if params != nil {
+		queryValues := queryURL.Query()
 
-	PutLinksIdWithResponse(ctx context.Context, id string, body PutLinksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutLinksIdResponse, error)
+		if params.Limit != nil {
 
-	// GetUsersWithResponse request
-	GetUsersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUsersResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	// PostUsersWithBodyWithResponse request with any body
-	PostUsersWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersResponse, error)
+		}
 
-	PostUsersWithResponse(ctx context.Context, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersResponse, error)
+		if params.Cursor != nil {
 
-	// DeleteUsersIdWithResponse request
-	DeleteUsersIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteUsersIdResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	// GetUsersIdWithResponse request
-	GetUsersIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetUsersIdResponse, error)
+		}
 
-	// PutUsersIdWithBodyWithResponse request with any body
-	PutUsersIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutUsersIdResponse, error)
+		if params.Sort != nil {
 
-	PutUsersIdWithResponse(ctx context.Context, id string, body PutUsersIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutUsersIdResponse, error)
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-type GetLinksResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]Link
-	JSON400      *Error
-	JSON500      *Error
-}
+		}
 
-// Status returns HTTPResponse.Status
-func (r GetLinksResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+		queryURL.RawQuery = queryValues.Encode()
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetLinksResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	return req, nil
 }
 
-type PostLinksResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *Error
-	JSON500      *Error
-}
-
-// Status returns HTTPResponse.Status
-func (r PostLinksResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewPostUsersRequest calls the generic PostUsers builder with application/json body
+func NewPostUsersRequest(server string, body PostUsersJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUsersRequestWithBody(server, "application/json", bodyReader)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostLinksResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewPostUsersRequestWithBody generates requests for PostUsers with any type of body
+func NewPostUsersRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetLinksUserUserIDResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]Link
-	JSON400      *Error
-	JSON404      *Error
-}
+	operationPath := fmt.Sprintf("/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetLinksUserUserIDResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetLinksUserUserIDResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type DeleteLinksIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON404      *Error
-}
+	req.Header.Add("Content-Type", contentType)
 
-// Status returns HTTPResponse.Status
-func (r DeleteLinksIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+	return req, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteLinksIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+// NewDeleteUsersIdRequest generates requests for DeleteUsersId
+func NewDeleteUsersIdRequest(server string, id string) (*http.Request, error) {
+	var err error
 
-type GetLinksIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Link
-	JSON404      *Error
-	JSON500      *Error
-}
+	var pathParam0 string
 
-// Status returns HTTPResponse.Status
-func (r GetLinksIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetLinksIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type PutLinksIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *Error
-	JSON404      *Error
-	JSON500      *Error
-}
+	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r PutLinksIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PutLinksIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetUsersResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]User
-	JSON400      *Error
-	JSON500      *Error
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetUsersResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewGetUsersIdRequest generates requests for GetUsersId
+func NewGetUsersIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetUsersResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type PostUsersResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *Error
-	JSON500      *Error
-}
+	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r PostUsersResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostUsersResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type DeleteUsersIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON404      *Error
-	JSON500      *Error
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r DeleteUsersIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewPutUsersIdRequest calls the generic PutUsersId builder with application/json body
+func NewPutUsersIdRequest(server string, id string, body PutUsersIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	bodyReader = bytes.NewReader(buf)
+	return NewPutUsersIdRequestWithBody(server, id, "application/json", bodyReader)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteUsersIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+// NewPutUsersIdRequestWithBody generates requests for PutUsersId with any type of body
+func NewPutUsersIdRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-type GetUsersIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *User
-	JSON404      *Error
-	JSON500      *Error
-}
+	var pathParam0 string
 
-// Status returns HTTPResponse.Status
-func (r GetUsersIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetUsersIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type PutUsersIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetApiKeysWithResponse request
+	GetApiKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiKeysResponse, error)
+
+	// PostApiKeysWithBodyWithResponse request with any body
+	PostApiKeysWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiKeysResponse, error)
+
+	PostApiKeysWithResponse(ctx context.Context, body PostApiKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiKeysResponse, error)
+
+	// DeleteApiKeysIdWithResponse request
+	DeleteApiKeysIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteApiKeysIdResponse, error)
+
+	// PostAuthLoginWithBodyWithResponse request with any body
+	PostAuthLoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error)
+
+	PostAuthLoginWithResponse(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error)
+
+	// PostAuthLogoutWithBodyWithResponse request with any body
+	PostAuthLogoutWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthLogoutResponse, error)
+
+	PostAuthLogoutWithResponse(ctx context.Context, body PostAuthLogoutJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthLogoutResponse, error)
+
+	// PostAuthRefreshWithBodyWithResponse request with any body
+	PostAuthRefreshWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthRefreshResponse, error)
+
+	PostAuthRefreshWithResponse(ctx context.Context, body PostAuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthRefreshResponse, error)
+
+	// GetLinksWithResponse request
+	GetLinksWithResponse(ctx context.Context, params *GetLinksParams, reqEditors ...RequestEditorFn) (*GetLinksResponse, error)
+
+	// PostLinksWithBodyWithResponse request with any body
+	PostLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLinksResponse, error)
+
+	PostLinksWithResponse(ctx context.Context, body PostLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostLinksResponse, error)
+
+	// GetLinksUserUserIDWithResponse request
+	GetLinksUserUserIDWithResponse(ctx context.Context, userID string, reqEditors ...RequestEditorFn) (*GetLinksUserUserIDResponse, error)
+
+	// DeleteLinksIdWithResponse request
+	DeleteLinksIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteLinksIdResponse, error)
+
+	// GetLinksIdWithResponse request
+	GetLinksIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetLinksIdResponse, error)
+
+	// PutLinksIdWithBodyWithResponse request with any body
+	PutLinksIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutLinksIdResponse, error)
+
+	PutLinksIdWithResponse(ctx context.Context, id string, body PutLinksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutLinksIdResponse, error)
+
+	// PostLinksIdRefreshWithResponse request
+	PostLinksIdRefreshWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostLinksIdRefreshResponse, error)
+
+	// GetUsersWithResponse request
+	GetUsersWithResponse(ctx context.Context, params *GetUsersParams, reqEditors ...RequestEditorFn) (*GetUsersResponse, error)
+
+	// PostUsersWithBodyWithResponse request with any body
+	PostUsersWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersResponse, error)
+
+	PostUsersWithResponse(ctx context.Context, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersResponse, error)
+
+	// DeleteUsersIdWithResponse request
+	DeleteUsersIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteUsersIdResponse, error)
+
+	// GetUsersIdWithResponse request
+	GetUsersIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetUsersIdResponse, error)
+
+	// PutUsersIdWithBodyWithResponse request with any body
+	PutUsersIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutUsersIdResponse, error)
+
+	PutUsersIdWithResponse(ctx context.Context, id string, body PutUsersIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutUsersIdResponse, error)
+}
+
+type GetApiKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ApiKey
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ApiKeyCreated
 	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiKeysIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON403      *Error
 	JSON404      *Error
 	JSON500      *Error
 }
 
 // Status returns HTTPResponse.Status
-func (r PutUsersIdResponse) Status() string {
+func (r DeleteApiKeysIdResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1118,142 +1851,886 @@ func (r PutUsersIdResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PutUsersIdResponse) StatusCode() int {
+func (r DeleteApiKeysIdResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-// GetLinksWithResponse request returning *GetLinksResponse
-func (c *ClientWithResponses) GetLinksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLinksResponse, error) {
-	rsp, err := c.GetLinks(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+type PostAuthLoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TokenPair
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthLoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
-	return ParseGetLinksResponse(rsp)
+	return http.StatusText(0)
 }
 
-// PostLinksWithBodyWithResponse request with arbitrary body returning *PostLinksResponse
-func (c *ClientWithResponses) PostLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLinksResponse, error) {
-	rsp, err := c.PostLinksWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthLoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
 	}
-	return ParsePostLinksResponse(rsp)
+	return 0
 }
 
-func (c *ClientWithResponses) PostLinksWithResponse(ctx context.Context, body PostLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostLinksResponse, error) {
-	rsp, err := c.PostLinks(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+type PostAuthLogoutResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthLogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
 	}
-	return ParsePostLinksResponse(rsp)
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthLogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthRefreshResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TokenPair
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthRefreshResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthRefreshResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLinksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LinkPage
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLinksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLinksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostLinksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
 }
 
-// GetLinksUserUserIDWithResponse request returning *GetLinksUserUserIDResponse
-func (c *ClientWithResponses) GetLinksUserUserIDWithResponse(ctx context.Context, userID string, reqEditors ...RequestEditorFn) (*GetLinksUserUserIDResponse, error) {
-	rsp, err := c.GetLinksUserUserID(ctx, userID, reqEditors...)
-	if err != nil {
-		return nil, err
+// Status returns HTTPResponse.Status
+func (r PostLinksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostLinksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLinksUserUserIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Link
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLinksUserUserIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLinksUserUserIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteLinksIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteLinksIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteLinksIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLinksIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Link
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLinksIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLinksIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutLinksIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutLinksIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutLinksIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostLinksIdRefreshResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostLinksIdRefreshResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostLinksIdRefreshResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserPage
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteUsersIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteUsersIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteUsersIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *User
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutUsersIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutUsersIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutUsersIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetApiKeysWithResponse request returning *GetApiKeysResponse
+func (c *ClientWithResponses) GetApiKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiKeysResponse, error) {
+	rsp, err := c.GetApiKeys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiKeysResponse(rsp)
+}
+
+// PostApiKeysWithBodyWithResponse request with arbitrary body returning *PostApiKeysResponse
+func (c *ClientWithResponses) PostApiKeysWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiKeysResponse, error) {
+	rsp, err := c.PostApiKeysWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiKeysResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiKeysWithResponse(ctx context.Context, body PostApiKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiKeysResponse, error) {
+	rsp, err := c.PostApiKeys(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiKeysResponse(rsp)
+}
+
+// DeleteApiKeysIdWithResponse request returning *DeleteApiKeysIdResponse
+func (c *ClientWithResponses) DeleteApiKeysIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteApiKeysIdResponse, error) {
+	rsp, err := c.DeleteApiKeysId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiKeysIdResponse(rsp)
+}
+
+// PostAuthLoginWithBodyWithResponse request with arbitrary body returning *PostAuthLoginResponse
+func (c *ClientWithResponses) PostAuthLoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error) {
+	rsp, err := c.PostAuthLoginWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLoginResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthLoginWithResponse(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error) {
+	rsp, err := c.PostAuthLogin(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLoginResponse(rsp)
+}
+
+// PostAuthLogoutWithBodyWithResponse request with arbitrary body returning *PostAuthLogoutResponse
+func (c *ClientWithResponses) PostAuthLogoutWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthLogoutResponse, error) {
+	rsp, err := c.PostAuthLogoutWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLogoutResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthLogoutWithResponse(ctx context.Context, body PostAuthLogoutJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthLogoutResponse, error) {
+	rsp, err := c.PostAuthLogout(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLogoutResponse(rsp)
+}
+
+// PostAuthRefreshWithBodyWithResponse request with arbitrary body returning *PostAuthRefreshResponse
+func (c *ClientWithResponses) PostAuthRefreshWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthRefreshResponse, error) {
+	rsp, err := c.PostAuthRefreshWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthRefreshResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthRefreshWithResponse(ctx context.Context, body PostAuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthRefreshResponse, error) {
+	rsp, err := c.PostAuthRefresh(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthRefreshResponse(rsp)
+}
+
+// GetLinksWithResponse request returning *GetLinksResponse
+func (c *ClientWithResponses) GetLinksWithResponse(ctx context.Context, params *GetLinksParams, reqEditors ...RequestEditorFn) (*GetLinksResponse, error) {
+	rsp, err := c.GetLinks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLinksResponse(rsp)
+}
+
+// PostLinksWithBodyWithResponse request with arbitrary body returning *PostLinksResponse
+func (c *ClientWithResponses) PostLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLinksResponse, error) {
+	rsp, err := c.PostLinksWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostLinksResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostLinksWithResponse(ctx context.Context, body PostLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostLinksResponse, error) {
+	rsp, err := c.PostLinks(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostLinksResponse(rsp)
+}
+
+// GetLinksUserUserIDWithResponse request returning *GetLinksUserUserIDResponse
+func (c *ClientWithResponses) GetLinksUserUserIDWithResponse(ctx context.Context, userID string, reqEditors ...RequestEditorFn) (*GetLinksUserUserIDResponse, error) {
+	rsp, err := c.GetLinksUserUserID(ctx, userID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLinksUserUserIDResponse(rsp)
+}
+
+// DeleteLinksIdWithResponse request returning *DeleteLinksIdResponse
+func (c *ClientWithResponses) DeleteLinksIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteLinksIdResponse, error) {
+	rsp, err := c.DeleteLinksId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteLinksIdResponse(rsp)
+}
+
+// GetLinksIdWithResponse request returning *GetLinksIdResponse
+func (c *ClientWithResponses) GetLinksIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetLinksIdResponse, error) {
+	rsp, err := c.GetLinksId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLinksIdResponse(rsp)
+}
+
+// PutLinksIdWithBodyWithResponse request with arbitrary body returning *PutLinksIdResponse
+func (c *ClientWithResponses) PutLinksIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutLinksIdResponse, error) {
+	rsp, err := c.PutLinksIdWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutLinksIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutLinksIdWithResponse(ctx context.Context, id string, body PutLinksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutLinksIdResponse, error) {
+	rsp, err := c.PutLinksId(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutLinksIdResponse(rsp)
+}
+
+// PostLinksIdRefreshWithResponse request returning *PostLinksIdRefreshResponse
+func (c *ClientWithResponses) PostLinksIdRefreshWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*PostLinksIdRefreshResponse, error) {
+	rsp, err := c.PostLinksIdRefresh(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostLinksIdRefreshResponse(rsp)
+}
+
+// GetUsersWithResponse request returning *GetUsersResponse
+func (c *ClientWithResponses) GetUsersWithResponse(ctx context.Context, params *GetUsersParams, reqEditors ...RequestEditorFn) (*GetUsersResponse, error) {
+	rsp, err := c.GetUsers(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersResponse(rsp)
+}
+
+// PostUsersWithBodyWithResponse request with arbitrary body returning *PostUsersResponse
+func (c *ClientWithResponses) PostUsersWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersResponse, error) {
+	rsp, err := c.PostUsersWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUsersWithResponse(ctx context.Context, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersResponse, error) {
+	rsp, err := c.PostUsers(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersResponse(rsp)
+}
+
+// DeleteUsersIdWithResponse request returning *DeleteUsersIdResponse
+func (c *ClientWithResponses) DeleteUsersIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteUsersIdResponse, error) {
+	rsp, err := c.DeleteUsersId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteUsersIdResponse(rsp)
+}
+
+// GetUsersIdWithResponse request returning *GetUsersIdResponse
+func (c *ClientWithResponses) GetUsersIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetUsersIdResponse, error) {
+	rsp, err := c.GetUsersId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersIdResponse(rsp)
+}
+
+// PutUsersIdWithBodyWithResponse request with arbitrary body returning *PutUsersIdResponse
+func (c *ClientWithResponses) PutUsersIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutUsersIdResponse, error) {
+	rsp, err := c.PutUsersIdWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutUsersIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutUsersIdWithResponse(ctx context.Context, id string, body PutUsersIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutUsersIdResponse, error) {
+	rsp, err := c.PutUsersId(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutUsersIdResponse(rsp)
+}
+
+// ParseGetApiKeysResponse parses an HTTP response from a GetApiKeysWithResponse call
+func ParseGetApiKeysResponse(rsp *http.Response) (*GetApiKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ApiKey
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiKeysResponse parses an HTTP response from a PostApiKeysWithResponse call
+func ParsePostApiKeysResponse(rsp *http.Response) (*PostApiKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ApiKeyCreated
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiKeysIdResponse parses an HTTP response from a DeleteApiKeysIdWithResponse call
+func ParseDeleteApiKeysIdResponse(rsp *http.Response) (*DeleteApiKeysIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiKeysIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthLoginResponse parses an HTTP response from a PostAuthLoginWithResponse call
+func ParsePostAuthLoginResponse(rsp *http.Response) (*PostAuthLoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthLoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TokenPair
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
-	return ParseGetLinksUserUserIDResponse(rsp)
-}
 
-// DeleteLinksIdWithResponse request returning *DeleteLinksIdResponse
-func (c *ClientWithResponses) DeleteLinksIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteLinksIdResponse, error) {
-	rsp, err := c.DeleteLinksId(ctx, id, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseDeleteLinksIdResponse(rsp)
+	return response, nil
 }
 
-// GetLinksIdWithResponse request returning *GetLinksIdResponse
-func (c *ClientWithResponses) GetLinksIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetLinksIdResponse, error) {
-	rsp, err := c.GetLinksId(ctx, id, reqEditors...)
+// ParsePostAuthLogoutResponse parses an HTTP response from a PostAuthLogoutWithResponse call
+func ParsePostAuthLogoutResponse(rsp *http.Response) (*PostAuthLogoutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetLinksIdResponse(rsp)
-}
 
-// PutLinksIdWithBodyWithResponse request with arbitrary body returning *PutLinksIdResponse
-func (c *ClientWithResponses) PutLinksIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutLinksIdResponse, error) {
-	rsp, err := c.PutLinksIdWithBody(ctx, id, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PostAuthLogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePutLinksIdResponse(rsp)
-}
 
-func (c *ClientWithResponses) PutLinksIdWithResponse(ctx context.Context, id string, body PutLinksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutLinksIdResponse, error) {
-	rsp, err := c.PutLinksId(ctx, id, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePutLinksIdResponse(rsp)
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
-// GetUsersWithResponse request returning *GetUsersResponse
-func (c *ClientWithResponses) GetUsersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUsersResponse, error) {
-	rsp, err := c.GetUsers(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseGetUsersResponse(rsp)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
 
-// PostUsersWithBodyWithResponse request with arbitrary body returning *PostUsersResponse
-func (c *ClientWithResponses) PostUsersWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersResponse, error) {
-	rsp, err := c.PostUsersWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
 	}
-	return ParsePostUsersResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostUsersWithResponse(ctx context.Context, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersResponse, error) {
-	rsp, err := c.PostUsers(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePostUsersResponse(rsp)
+	return response, nil
 }
 
-// DeleteUsersIdWithResponse request returning *DeleteUsersIdResponse
-func (c *ClientWithResponses) DeleteUsersIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteUsersIdResponse, error) {
-	rsp, err := c.DeleteUsersId(ctx, id, reqEditors...)
+// ParsePostAuthRefreshResponse parses an HTTP response from a PostAuthRefreshWithResponse call
+func ParsePostAuthRefreshResponse(rsp *http.Response) (*PostAuthRefreshResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteUsersIdResponse(rsp)
-}
 
-// GetUsersIdWithResponse request returning *GetUsersIdResponse
-func (c *ClientWithResponses) GetUsersIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetUsersIdResponse, error) {
-	rsp, err := c.GetUsersId(ctx, id, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PostAuthRefreshResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetUsersIdResponse(rsp)
-}
 
-// PutUsersIdWithBodyWithResponse request with arbitrary body returning *PutUsersIdResponse
-func (c *ClientWithResponses) PutUsersIdWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutUsersIdResponse, error) {
-	rsp, err := c.PutUsersIdWithBody(ctx, id, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePutUsersIdResponse(rsp)
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TokenPair
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
 
-func (c *ClientWithResponses) PutUsersIdWithResponse(ctx context.Context, id string, body PutUsersIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutUsersIdResponse, error) {
-	rsp, err := c.PutUsersId(ctx, id, body, reqEditors...)
-	if err != nil {
-		return nil, err
 	}
-	return ParsePutUsersIdResponse(rsp)
+
+	return response, nil
 }
 
 // ParseGetLinksResponse parses an HTTP response from a GetLinksWithResponse call
@@ -1271,7 +2748,7 @@ func ParseGetLinksResponse(rsp *http.Response) (*GetLinksResponse, error) {
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []Link
+		var dest LinkPage
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1284,6 +2761,20 @@ func ParseGetLinksResponse(rsp *http.Response) (*GetLinksResponse, error) {
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1317,6 +2808,13 @@ func ParsePostLinksResponse(rsp *http.Response) (*PostLinksResponse, error) {
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1357,6 +2855,20 @@ func ParseGetLinksUserUserIDResponse(rsp *http.Response) (*GetLinksUserUserIDRes
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1383,6 +2895,13 @@ func ParseDeleteLinksIdResponse(rsp *http.Response) (*DeleteLinksIdResponse, err
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1390,6 +2909,13 @@ func ParseDeleteLinksIdResponse(rsp *http.Response) (*DeleteLinksIdResponse, err
 		}
 		response.JSON404 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
 
 	return response, nil
@@ -1416,6 +2942,13 @@ func ParseGetLinksIdResponse(rsp *http.Response) (*GetLinksIdResponse, error) {
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1456,6 +2989,53 @@ func ParsePutLinksIdResponse(rsp *http.Response) (*PutLinksIdResponse, error) {
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostLinksIdRefreshResponse parses an HTTP response from a PostLinksIdRefreshWithResponse call
+func ParsePostLinksIdRefreshResponse(rsp *http.Response) (*PostLinksIdRefreshResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostLinksIdRefreshResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1490,7 +3070,7 @@ func ParseGetUsersResponse(rsp *http.Response) (*GetUsersResponse, error) {
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []User
+		var dest UserPage
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1503,6 +3083,20 @@ func ParseGetUsersResponse(rsp *http.Response) (*GetUsersResponse, error) {
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1536,6 +3130,13 @@ func ParsePostUsersResponse(rsp *http.Response) (*PostUsersResponse, error) {
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1562,6 +3163,20 @@ func ParseDeleteUsersIdResponse(rsp *http.Response) (*DeleteUsersIdResponse, err
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1602,6 +3217,20 @@ func ParseGetUsersIdResponse(rsp *http.Response) (*GetUsersIdResponse, error) {
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1634,13 +3263,27 @@ func ParsePutUsersIdResponse(rsp *http.Response) (*PutUsersIdResponse, error) {
 		HTTPResponse: rsp,
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON403 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
@@ -1663,9 +3306,27 @@ func ParsePutUsersIdResponse(rsp *http.Response) (*PutUsersIdResponse, error) {
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
-	// Получить все объекты Link
+	// Получить API-ключи текущего пользователя
+	// (GET /api-keys)
+	GetApiKeys(w http.ResponseWriter, r *http.Request)
+	// Выпустить API-ключ текущего пользователя
+	// (POST /api-keys)
+	PostApiKeys(w http.ResponseWriter, r *http.Request)
+	// Отозвать API-ключ по ID
+	// (DELETE /api-keys/{id})
+	DeleteApiKeysId(w http.ResponseWriter, r *http.Request, id string)
+	// Войти по имени пользователя и паролю
+	// (POST /auth/login)
+	PostAuthLogin(w http.ResponseWriter, r *http.Request)
+	// Отозвать refresh token и связанную с ним сессию
+	// (POST /auth/logout)
+	PostAuthLogout(w http.ResponseWriter, r *http.Request)
+	// Обменять refresh token на новую пару токенов
+	// (POST /auth/refresh)
+	PostAuthRefresh(w http.ResponseWriter, r *http.Request)
+	// Найти объекты Link
 	// (GET /links)
-	GetLinks(w http.ResponseWriter, r *http.Request)
+	GetLinks(w http.ResponseWriter, r *http.Request, params GetLinksParams)
 	// Создать новый объект Link
 	// (POST /links)
 	PostLinks(w http.ResponseWriter, r *http.Request)
@@ -1681,9 +3342,12 @@ type ServerInterface interface {
 	// Обновить объект Link по ID
 	// (PUT /links/{id})
 	PutLinksId(w http.ResponseWriter, r *http.Request, id string)
+	// Повторно получить метаданные страницы ссылки
+	// (POST /links/{id}/refresh)
+	PostLinksIdRefresh(w http.ResponseWriter, r *http.Request, id string)
 	// Получить всех пользователей
 	// (GET /users)
-	GetUsers(w http.ResponseWriter, r *http.Request)
+	GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams)
 	// Создать нового пользователя
 	// (POST /users)
 	PostUsers(w http.ResponseWriter, r *http.Request)
@@ -1702,9 +3366,45 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
-// Получить все объекты Link
+// Получить API-ключи текущего пользователя
+// (GET /api-keys)
+func (_ Unimplemented) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Выпустить API-ключ текущего пользователя
+// (POST /api-keys)
+func (_ Unimplemented) PostApiKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отозвать API-ключ по ID
+// (DELETE /api-keys/{id})
+func (_ Unimplemented) DeleteApiKeysId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Войти по имени пользователя и паролю
+// (POST /auth/login)
+func (_ Unimplemented) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отозвать refresh token и связанную с ним сессию
+// (POST /auth/logout)
+func (_ Unimplemented) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Обменять refresh token на новую пару токенов
+// (POST /auth/refresh)
+func (_ Unimplemented) PostAuthRefresh(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Найти объекты Link
 // (GET /links)
-func (_ Unimplemented) GetLinks(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) GetLinks(w http.ResponseWriter, r *http.Request, params GetLinksParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -1738,9 +3438,15 @@ func (_ Unimplemented) PutLinksId(w http.ResponseWriter, r *http.Request, id str
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Повторно получить метаданные страницы ссылки
+// (POST /links/{id}/refresh)
+func (_ Unimplemented) PostLinksIdRefresh(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Получить всех пользователей
 // (GET /users)
-func (_ Unimplemented) GetUsers(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -1777,12 +3483,230 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// GetApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostApiKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteApiKeysId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiKeysId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiKeysId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAuthLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLogout(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAuthRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthRefresh(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthRefresh(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetLinks operation middleware
 func (siw *ServerInterfaceWrapper) GetLinks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinksParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "match" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "match", r.URL.Query(), &params.Match)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "match", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "broken" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "broken", r.URL.Query(), &params.Broken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "broken", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "updated_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "updated_after", r.URL.Query(), &params.UpdatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "updated_after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "updated_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "updated_before", r.URL.Query(), &params.UpdatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "updated_before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetLinks(w, r)
+		siw.Handler.GetLinks(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1796,6 +3720,10 @@ func (siw *ServerInterfaceWrapper) GetLinks(w http.ResponseWriter, r *http.Reque
 func (siw *ServerInterfaceWrapper) PostLinks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.PostLinks(w, r)
 	}))
@@ -1822,6 +3750,10 @@ func (siw *ServerInterfaceWrapper) GetLinksUserUserID(w http.ResponseWriter, r *
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetLinksUserUserID(w, r, userID)
 	}))
@@ -1848,6 +3780,10 @@ func (siw *ServerInterfaceWrapper) DeleteLinksId(w http.ResponseWriter, r *http.
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.DeleteLinksId(w, r, id)
 	}))
@@ -1874,6 +3810,10 @@ func (siw *ServerInterfaceWrapper) GetLinksId(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetLinksId(w, r, id)
 	}))
@@ -1900,6 +3840,10 @@ func (siw *ServerInterfaceWrapper) PutLinksId(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.PutLinksId(w, r, id)
 	}))
@@ -1911,12 +3855,75 @@ func (siw *ServerInterfaceWrapper) PutLinksId(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// PostLinksIdRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostLinksIdRefresh(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostLinksIdRefresh(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetUsers operation middleware
 func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUsers(w, r)
+		siw.Handler.GetUsers(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1956,6 +3963,10 @@ func (siw *ServerInterfaceWrapper) DeleteUsersId(w http.ResponseWriter, r *http.
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.DeleteUsersId(w, r, id)
 	}))
@@ -1982,6 +3993,10 @@ func (siw *ServerInterfaceWrapper) GetUsersId(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetUsersId(w, r, id)
 	}))
@@ -2008,6 +4023,10 @@ func (siw *ServerInterfaceWrapper) PutUsersId(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.PutUsersId(w, r, id)
 	}))
@@ -2132,6 +4151,24 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api-keys", wrapper.GetApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api-keys", wrapper.PostApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api-keys/{id}", wrapper.DeleteApiKeysId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/login", wrapper.PostAuthLogin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/logout", wrapper.PostAuthLogout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/refresh", wrapper.PostAuthRefresh)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/links", wrapper.GetLinks)
 	})
@@ -2150,6 +4187,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/links/{id}", wrapper.PutLinksId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/links/{id}/refresh", wrapper.PostLinksIdRefresh)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users", wrapper.GetUsers)
 	})
@@ -2172,24 +4212,68 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+yY3W7bNhTHX0XgdinU7pbd+G5btsFAL4oNuSqKQrUYl61FqiTVITAE1A2GFUuBPsE6",
-	"FHsBN6uRr9l5hcM3Kg7pD9mWP5Q6toPmxpZofpxz+OOf57hJaiKKBadcK1JpElV7QqPAPv4kpZD4EEsR",
-	"U6kZtc01EVL8pjyJSOUB4UL/LBIeEp/UBN9vsJomPnkchL/S5wlV+MK4ppIHjd+ofEGlm/ehT/RBTEmF",
-	"KC0Zr5PUJxFVKqjb2Sd+S30i6fOESRrimtaG0Qzi8VNa0zjDPcaf5ZgsaaBp+CjQOVP7hIX5zVFQd+OZ",
-	"ppHK7dNvCKQMDux7UC86gukGze2ZxOE8qxPZyG9XVD7KdWkiiAy3zC3vZhuN7TsyjIGfDeGYZbM24Ufb",
-	"f3ortjXW1xDMyRAO5soL2Z6iclXcxoFSvwsZXgkqhQc1oks6POyeWbUYK+h4QVbm+/f5DkybiUMZ3xd2",
-	"UseQhdwLeOihB97396vEJy+oVExwUiF375TvlNEeEVMexIxUyLe2CdfRT6x7pQbjz+xTndrdQN8DzQSv",
-	"hqRCfqH6nu2AZqtYcOWi8k257GSYa8rtuCCOG6xmR5aeKlx/oONjx+NrSfdJhXxVGil+qS/3JaubUycG",
-	"/Q6pqkkWa+cXvIdLODUt6MG5Bz34YP6CDpybV9CDY5xgp6B184xyF0WeFX9DB46hY15C1xzBmQcn0IZL",
-	"8xJ6poVWfLcWK96Z13AKH+Ac2p5pWXOcUW0Lm0qiKJAH2PMf6MGFOTR/wql5Zd54cIz9xwJojrzBJsRC",
-	"5fBwX6gMEPZq/UGEByvzM6Pa6fhZ0TKh6RSFd/FrMiIjfzxzaFpwCR3zGrrQ8ywzJ/AR2tC9BWUWKO8H",
-	"QXKYdPFYOcMzqPRBSf2+hJRQxUpN/KzupgsFBQVrz/a1aiSDiGoqFak8aBKG9qJCEZ84GSXJoOs4EH4m",
-	"KpNC+3BrJcu0TMscwQW+bA+FO+WdNVjhROgNnCBUSBh08B0p6+BHG87gI3TweM5Xr2EQz+HUR6CPzVvr",
-	"Utf61/FMy4PLvNWgA/9nwW2yMHUy0qAuAxhHdte2W2qr4VK02lv9c0jdKShrh1bSLlzchlu5eV3xr33x",
-	"TFAWIvRvP0r9629SzCwuXnUXzZ6rXeuioLzSa3WZ+GVCty5BWLCDNyCNmsdRnOQlUcm1c7T5zKyghGEU",
-	"XaaRlbEv5168ccfg3XC/Fh4DvGoxgZtbZu7ZDuvI2ezfHIXLzFmpxNltHVGs4DR/LAjm7MpzxMjq9S3z",
-	"H9CVK89Zye1tFbqiKhR68B9eFrn8mLcZqVkyq7dEbTKrXxaZ/Ax/e4q17eNnItufgcwySf9aISmvVNGK",
-	"busGCoAbDdnUFbcEZrNqguvGbPN3ZvnqAvil1wc3+pRM1QoLT0mapp8CAAD//6jRHsMVIAAA",
+	"H4sIAAAAAAAC/+xcW28bx/X/Kov95yEBVhIty/4nfHPrNnXjAIZjowFcVVhxh9JG5C49u+tINQhIZBPH",
+	"lSuhQR+CALn2oa+UIlq0LvRXmPlGxTkze5/lRaBsquGLLe5l9sy5n985u0/1iltvuA5xfE8vP9W9yjqp",
+	"m/jnrYb9EdmCvxrUbRDq2wSPVygxfWKtmD788rcaRC/rnk9tZ01vGjrZbNiUeEWnbUt5uGZ6/krgFS/r",
+	"mHWiPNGgpGpvKk95FbchaLZ9UveU18gDJqXmlt5sGjoljwObEksvPwJi5ZOj50SrGklGLEcLuaufkYoP",
+	"Kwv+/RYvgkeblmX7tuuYtXsJflbNmkeMDIvTPLSIV6F2A+7Vyzr7mm+zLjvj+xrr8R3eYl3+jHXZOevB",
+	"oRN2yvf4M9YxNHbAuuxYY+esy35h/egcnuA7fIdvsz5/xs75LnulG3rVpXV4om6ZPpnzbdx2oSDq5uZd",
+	"4qz563r52uL7hl63nei3MUgW6e3UbGfDK1NiWtqcxlusz075C3bC+hp/xltiX6yrIbm77JT12YmhVYNa",
+	"TZvT2CHfYV2NvebbrMMOWUdjr+UCx6wPB3CFU76vvQtnNN5mZ3jBM9ZBhu29pxuxchAnqIPYY5qAK0Gt",
+	"pi8rdjRQc5BLw7TCyhuX2bBXNoTVvUNJVS/r/7cQW+iCNM8FsQqsJy/OUJehJlxVXK4i63cOtSvrdeKo",
+	"NO4n1kdF6/N9KQ7J53ZS885Yl7dYhx0hb8/5Lv8CxNZC4cA1X/LdWI4nrKdn1d70fVJv+AotYT+wPnvN",
+	"d3kLbtT4DlLAd9gp67Ij1PBX8hDScI7ih0vZoYZK3kWrOeIvkopuO/71xVjJbccna4QCP1apu0EcFS8i",
+	"+jtoWbB6i53wbb4LCgc84DvAjnOwMXYi9REUGvUU6Ori/yeCi0d8m++zo5iKVdetEdMBKiqu45FK4NtP",
+	"yErVtGsBVZkQ+451eRv4Lo25m34UsiH9qBE4sO77jRXPN/3AU7KhBdbF2ypRoLMBthwKlRjtgRgCpApI",
+	"zzeaR8IbCaUuVXp4Siybkoq/EtCaYiffCtbBLoSOnLNO5FFO+T5sQXt4/25WdfMezpeOPv0Ab8NuNAh6",
+	"t7QxtIVnRm/F94WEhEn1eIu/0FiPnYKu/wMsT+jaHx58fNfQVmtuZQNXTNzTwXvyjziWu+ny50I7Nequ",
+	"ur4372/6Gt9hHfZKiij0fg3iWLAlQ3c3QHKmXSPgCOVGdEOXBCi8YsbvCJYYsWEXqHRkb0rXFEo2k4a4",
+	"Fkk6bcf1f+8GjiWeUa3ZFR/WNa375HFAPPgROGbgr7vU/ituo+rSVduyiAPSdN2PTWdLXgsUgWZSx6x9",
+	"QugTQgURhu4R+sSukIeO+cS0a+ZqDba3Zvrkc3PrgV0nbuArg0WdeJ65RgoUFB+6Ylt57WHfsCPQSd5i",
+	"Pf431gO/g554OxYtGF/HAGGCxb+WLhhEzXfEVb+AmkiX2Gdn2qdzcqNzdyx9mBCR0SrB3LWdjfHTw1Sg",
+	"GRTjEiGpOG+06+baeCmeofvm2rh32H5NLbugYQ3arXQ5+eMeoSvKLakyUPF4sVp8r9xIxINUQpqiLMX1",
+	"IkleKFFVquzP2ZjX01ifHcigfCpzup6hsSO4kr2EIxn1ld5Mu3MbI/hr3uYtkTGAL6HwnL88Ks19YM5V",
+	"l58uLjXfUXlkhXJEESWgtm4kk9jF0tL7KsM1N++Im68vjqBKiQVvLg1NiocsHmpdYtEb1xaNQjUbc3MZ",
+	"VYNFipTjnvRdaeljnpza/iB7hnVUxuWQTX+lElDPpQpl+pa3+TboBt/WwiSDt/kef445XzbBNDDx4Dug",
+	"L3COHfI2ukII66qkMb0A6w51h2LTSka5a7YTRpvx7Khhet7nLrUy0v5/pbA9QhVF2M2l3LVZAYc3GvHz",
+	"VPu4T6qUeOsX2wkVN6/4Yf6c1MIbN4fRmL5dRd0DOHPPtBUpgVmpEM+LH12ITdhOylxsx7+5pMxJc7vJ",
+	"hwo4syIODy3DkvRlF08tlSJVxYWHHqFjx96CGErd2rghdFjYS6joCPEtoZiCluJQVsSLC0WvAVY3xHEP",
+	"sMGBd17QImGHahc87c5TsGr0IIF6PQxcEUsWMeohasskEpl/jZ+exCE4sC0VO8bx9JFhZuj6EenqFaJd",
+	"hoYw10shuzOsH/YFyUmADbdyxnooPSlJUVskQbHBIUbhGsYJTxkBQhVNKgG1/a1PQCEiTOwjsnUrgGWe",
+	"6uC79XViWoSG4GxZ/3Tu1r07cx8hxBUSFEFkq8SkhIb3p3l5C12yhq5Xe/ePf3rwXlh3w4IhYoqoL9Cj",
+	"l+Vq8XMAJ9GbQLrtVF30eCJvw8RJMx1LA62E9XRDf0KoJ558bb40XwLy3AZxzIatl/XreAhz3HXc+YLZ",
+	"sOc2yBb+WCPobEFrTaD+jqWX9Q+JL7BADwOK14DyGi9fLJVEmez4stgyG42aXcF7Fz7zXCfG+0c2zxh3",
+	"zBho08iDhq9BrQTQJfkITgNuXipdG4u2gWUiVuYqCn5GOOeAt2NQroOGmq2nvwQAU9B1/Q3Q9R16UAlV",
+	"osE9g9oowrGBkhul0hug5Hv+FeuxAwQzAUrn2xIy7AhbDOp1k26F+GsSnEpaB4BUwNQT3ubPQ/ivwDWh",
+	"C3Q9FcqcEpfwVCfoekNm8Ta43zLA/rvocnd4S5ITtTYkHAn+jz/nL/iehqhnG4hCwlKEd1IQ3PyfHd3I",
+	"2Nc910sZGKbEv3GtrYlJJ9UmaqZDnU8D0szZ9bVLebal1JBvJV8jnoOAzw2Alc4Rbw4bNEmeHrI+O2aH",
+	"GE+eJzDxdPTpsyOIPRpcxo6F+ZXekPkJLcfGVwpHmzmnYc4pDI9JroHvAJTyFDJBMMGUkfXZ2TR6tK9z",
+	"TiRJ9TgerWnEgXrhqW01hXOrEZGFph3KbTwuXQqirw2TmnXiY5b8SOY3kAHE2Q1mkmmvYCQ4NTjnNPTN",
+	"uTV3Lpt7LefcypKqgAjNv4+2ewwbZ+czKxklhC+Vlt4AJZGEsBcJvatXomMwjTb3faxFeYvDDv2d29Ke",
+	"An99oQaAGta8MmNQxObAX0fc7ZKicwrTGyk6T47jMdKl4voPrAMsTiRK4JdEoBYt+M6vLaYmqGB9MIee",
+	"GJMpGgoJQ9lrZCVeMz1WI+tgvfxoOR23WB9btqL8xz2KJGzQPpN73EtbGHQsRzExuO5ybCyDN49kZUvK",
+	"MRWcahLTKMfYv+/ybf6V6OlPjzFMt35lfbREqSVEgsM37JDvs2M55tPme1h1QRlwJp4khJDUM7nIcEWT",
+	"ujBFmjbz52/Ln99Pa9455lxd9kpA06wn/Nt05TqFVsUOUkAsze4NoYg+IO58T3pr3s4ogzCoqOsqAcEM",
+	"hf/EoU85JKCsxVOTTHKIkn9RED4Ezg9/nhkaDBSdSCAF7Ry2Eg1L9TUkrVw3HXONGNJX9NmBFFhXjAWy",
+	"boaAcGgPng3nz+Y19h/eZi8h0kU74a1kLZAc+kw+VYXhfEh8gGK9fK2VnazFClZUsl3+ZWroFCeC9LL+",
+	"OCB0Ky7N4mGMWLNyMLdiQgJqyp4hBc06YniSb4fA/XlYcB4KQF5ojWgL1nDwSRSBKpJ8c003VMDuUOje",
+	"87cQuIaCUs+TbTpb2lzMFGkCAOOAAbwEg9T4F9hC2tfYAd+NMR7WY8dCiwCHOzQ0MznAK0/0CnhcN/3K",
+	"empLFqmaQc0XNCVG18QvUzmwqxADgprAa1ns44wrOlwxTIr49YnMszJzVGAKPZwLlGPWYhgWFFlDRf0l",
+	"bqoU7OvxeFoDEs/OSLMDxC6yNmVo2OTKXo2adoDFao91C6iSk3gK0qLBVAUrf0I3g3GK74piVDQG+QtI",
+	"wGDy+gRgPpE0hC7kjPWlV2wVcinqBFd9QlNkjTIdOpTUCVO5SqouJZMg8/v0xNSEuRq11CfF1Ty5E6Z0",
+	"cpz9EQkBj7utaoGrXxTQbpQMIQF2IEwK9wVZh5ruml23fTW54Qx03dy06+C5bpRKODggfl3LT6Lkd5Fo",
+	"/ItYCK39XXYkEct8c79IcXGJYZ5IdafnUr/AK6emN0LnnDo4p3x5ZgA6ObnkLppnU3Ytk0yDpAzSl79j",
+	"dGiJFGzWppj1UC+Gfn4nh+17Ka3iu1o4GVlcGof566VAnPHw7+jtx7z3D/cDidAOvkzxFUqE78TxdmY9",
+	"w6xn2nQ2SpZEqXce58cJFZYKHBWnC1AVLTyFf+/cbg6aXUG9xqEtvHakblgQXnrpHbFLGKBRD0EPGZ9J",
+	"1aEzE5p1APNVbA71f6HqCA6e6UlXkBmYV5SYxSDRWdL+R2uCo/FPpgU++lshIza+BwU0fF8wBh6n1w7e",
+	"hPYlGXUletD/ltITOp8LY3Eb2hgctaZAcSdbE40i3pRkZ4p/pRQ/6+4Hqn4jUFUiwdtU/bdf+YwZKLIv",
+	"Pc5St5nlXmxsKtKjoZabzsFUffdc9ii+enAoejWp7iD0OA6xgM98/kN+IkB0+LB3h6RprMu6+S9uyPZm",
+	"/hXg7rzGfoTmCW8joIqZNLQse0IW4t0a7Ll0tfgV5lSiOq8lPsmBg+gddgKvvqS+gcH3+V6og+I7Dz25",
+	"32fyMx29eeXst/R38VjC2434i8O+SBJ/AiVkcycvkJkLuJLBW/aC4+ZwKprnPr8jeoIDPr+DziJ6Pa8o",
+	"2X2IFwxrnc96Kr/qnkr0gupIPZXCQZNZhjTrrkysvBk+1zS45xI6vsuoPBKvrF+451KE+12R/ssHb4CK",
+	"bwbPf+OMWVfQhoN5rD/9k4Sqjkw4TzHwFSWM9CNCs6j7U/920qgWcGUA21njoqBxMfUwcuGrF8PR5Omz",
+	"tcnmhePK/opgzDNbvTK2mksNR7DWIgB8Kqz1chJS+eGc0d+KuVg4nsHiM2fzP+xscmD9UGeTSfefpj5Y",
+	"9Gi5aaQ/gfRoubnc/O8Am4Qb9rhfAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,171 @@
+// Package hostlimit limits the rate and the concurrency of requests per host.
+package hostlimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// idleTTL is how long the state of a host without requests is kept.
+const idleTTL = 10 * time.Minute
+
+// Limiter is a token bucket with a semaphore per host. The zero rate
+// disables the rate limit and the zero concurrency the concurrency limit.
+type Limiter struct {
+	rate        float64
+	burst       int
+	concurrency int
+
+	mu        sync.Mutex
+	hosts     map[string]*host
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type host struct {
+	tokens   float64
+	last     time.Time
+	interval time.Duration
+	sem      chan struct{}
+	users    int
+}
+
+// New returns a Limiter allowing rate requests per second with bursts of
+// burst requests, and up to concurrency requests at once, to every host.
+func New(rate float64, burst, concurrency int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &Limiter{
+		rate:        rate,
+		burst:       burst,
+		concurrency: concurrency,
+		hosts:       make(map[string]*host),
+		now:         time.Now,
+	}
+}
+
+// SetMinInterval makes the requests to host at least interval apart, when
+// that is slower than the rate of the Limiter.
+func (l *Limiter) SetMinInterval(hostname string, interval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.host(hostname).interval = interval
+}
+
+// Wait blocks until a request to host is allowed or ctx is done. The returned
+// release must be called when the request is complete.
+func (l *Limiter) Wait(ctx context.Context, hostname string) (release func(), err error) {
+	l.mu.Lock()
+	h := l.host(hostname)
+	h.users++
+	l.mu.Unlock()
+
+	done := func() {
+		l.mu.Lock()
+		h.users--
+		l.mu.Unlock()
+	}
+
+	if h.sem != nil {
+		select {
+		case h.sem <- struct{}{}:
+		case <-ctx.Done():
+			done()
+			return nil, ctx.Err()
+		}
+	}
+
+	release = func() {
+		if h.sem != nil {
+			<-h.sem
+		}
+		done()
+	}
+
+	for {
+		delay := l.reserve(h)
+		if delay == 0 {
+			return release, nil
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			release()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token of h, or returns how long to wait for one.
+func (l *Limiter) reserve(h *host) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rate := l.rate
+	if h.interval > 0 && (rate == 0 || 1/rate < h.interval.Seconds()) {
+		rate = 1 / h.interval.Seconds()
+	}
+	if rate == 0 {
+		return 0
+	}
+
+	burst := float64(l.burst)
+	if h.interval > 0 {
+		// a minimum interval leaves no room for bursts
+		burst = 1
+	}
+
+	now := l.now()
+	h.tokens += now.Sub(h.last).Seconds() * rate
+	if h.tokens > burst {
+		h.tokens = burst
+	}
+	h.last = now
+
+	if h.tokens >= 1 {
+		h.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - h.tokens) / rate * float64(time.Second))
+}
+
+// host returns the state of hostname, creating it with a full bucket. l.mu
+// must be held.
+func (l *Limiter) host(hostname string) *host {
+	now := l.now()
+	l.sweep(now)
+
+	h, ok := l.hosts[hostname]
+	if !ok {
+		h = &host{tokens: float64(l.burst), last: now}
+		if l.concurrency > 0 {
+			h.sem = make(chan struct{}, l.concurrency)
+		}
+		l.hosts[hostname] = h
+	}
+
+	return h
+}
+
+// sweep forgets the hosts without requests for idleTTL, so that the state
+// does not grow with every host ever seen. l.mu must be held.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleTTL {
+		return
+	}
+	l.lastSweep = now
+
+	for name, h := range l.hosts {
+		if h.users == 0 && now.Sub(h.last) > idleTTL {
+			delete(l.hosts, name)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package hostlimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		limiter  *Limiter
+		host     string
+		interval time.Duration
+		requests int
+		min      time.Duration
+		max      time.Duration
+	}{
+		{
+			name:     "test_burst_is_not_delayed",
+			limiter:  New(10, 3, 0),
+			requests: 3,
+			max:      50 * time.Millisecond,
+		},
+		{
+			name:     "test_over_burst_is_delayed",
+			limiter:  New(10, 1, 0),
+			requests: 3,
+			min:      150 * time.Millisecond,
+		},
+		{
+			name:     "test_min_interval_is_slower_than_rate",
+			limiter:  New(1000, 5, 0),
+			interval: 100 * time.Millisecond,
+			requests: 2,
+			min:      80 * time.Millisecond,
+		},
+		{
+			name:     "test_zero_rate_is_unlimited",
+			limiter:  New(0, 0, 0),
+			requests: 100,
+			max:      50 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.interval > 0 {
+				tt.limiter.SetMinInterval("example.com", tt.interval)
+			}
+
+			start := time.Now()
+			for i := 0; i < tt.requests; i++ {
+				release, err := tt.limiter.Wait(context.Background(), "example.com")
+				if err != nil {
+					t.Fatalf("Wait() error = %v", err)
+				}
+				release()
+			}
+			elapsed := time.Since(start)
+
+			if elapsed < tt.min {
+				t.Errorf("%d requests took %v, want at least %v", tt.requests, elapsed, tt.min)
+			}
+			if tt.max > 0 && elapsed > tt.max {
+				t.Errorf("%d requests took %v, want at most %v", tt.requests, elapsed, tt.max)
+			}
+		})
+	}
+}
+
+func TestLimiterConcurrency(t *testing.T) {
+	l := New(0, 0, 1)
+
+	release, err := l.Wait(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	// other hosts are not affected
+	other, err := l.Wait(context.Background(), "example.org")
+	if err != nil {
+		t.Fatalf("Wait() for another host error = %v", err)
+	}
+	other()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.Wait(ctx, "example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait() over concurrency error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	release()
+
+	release, err = l.Wait(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Wait() after release error = %v", err)
+	}
+	release()
+}
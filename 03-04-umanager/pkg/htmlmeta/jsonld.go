@@ -0,0 +1,111 @@
+package htmlmeta
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// parseJSONLD reads the metadata of the schema.org objects in a JSON-LD
+// script. The content fields are taken from the objects with a headline
+// only, so that an Organization does not describe the page.
+func (p *parser) parseJSONLD(script string) {
+	var v any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(script)), &v); err != nil {
+		return
+	}
+
+	p.walkJSONLD(v)
+}
+
+func (p *parser) walkJSONLD(v any) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			p.walkJSONLD(item)
+		}
+	case map[string]any:
+		if graph, ok := v["@graph"]; ok {
+			p.walkJSONLD(graph)
+		}
+		p.parseJSONLDObject(v)
+	}
+}
+
+func (p *parser) parseJSONLDObject(o map[string]any) {
+	if ldType(o) == "WebSite" {
+		p.set(&p.m.SiteName, sourceJSONLD, ldString(o["name"]))
+		return
+	}
+
+	headline := ldString(o["headline"])
+	if headline == "" {
+		return
+	}
+
+	p.set(&p.m.Title, sourceJSONLD, headline)
+	p.set(&p.m.Description, sourceJSONLD, ldString(o["description"]))
+	p.set(&p.m.Type, sourceJSONLD, ldType(o))
+	p.set(&p.m.Author, sourceJSONLD, ldName(o["author"]))
+	p.set(&p.published, sourceJSONLD, ldString(o["datePublished"]))
+	p.set(&p.m.Canonical, sourceJSONLD, ldString(o["url"]))
+	p.set(&p.m.SiteName, sourceJSONLD, ldName(o["publisher"]))
+	for _, img := range ldURLs(o["image"]) {
+		p.addImage(sourceJSONLD, img)
+	}
+}
+
+// ldType returns the first type of o.
+func ldType(o map[string]any) string {
+	switch t := o["@type"].(type) {
+	case string:
+		return t
+	case []any:
+		if len(t) > 0 {
+			return ldString(t[0])
+		}
+	}
+
+	return ""
+}
+
+func ldString(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+// ldName returns the name of the first thing or person in v.
+func ldName(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case map[string]any:
+		return ldString(v["name"])
+	case []any:
+		if len(v) > 0 {
+			return ldName(v[0])
+		}
+	}
+
+	return ""
+}
+
+// ldURLs returns the URLs of the images in v.
+func ldURLs(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case map[string]any:
+		if u := ldString(v["url"]); u != "" {
+			return []string{u}
+		}
+		return ldURLs(v["contentUrl"])
+	case []any:
+		var res []string
+		for _, item := range v {
+			res = append(res, ldURLs(item)...)
+		}
+		return res
+	}
+
+	return nil
+}
@@ -4,56 +4,143 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
 	"strings"
+	"time"
 
 	"golang.org/x/net/html"
 )
 
+// Meta is the metadata of a page. A field found in several sources is taken
+// from the first of OpenGraph, Twitter Card, JSON-LD and the plain HTML tags.
 type Meta struct {
-	Title       string   `json:"title"`
-	Description string   `json:"description,omitempty"`
-	Tags        []string `json:"keywords,omitempty"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Tags        []string   `json:"keywords,omitempty"`
+	Images      []string   `json:"images,omitempty"`
+	SiteName    string     `json:"site_name,omitempty"`
+	Author      string     `json:"author,omitempty"`
+	Published   *time.Time `json:"published,omitempty"`
+	Canonical   string     `json:"canonical,omitempty"`
+	Favicon     string     `json:"favicon,omitempty"`
+	Type        string     `json:"type,omitempty"`
 }
 
+// source is where a field is found. The greater sources take precedence.
+type source int
+
+const (
+	sourceHTML source = iota + 1
+	sourceJSONLD
+	sourceTwitter
+	sourceOpenGraph
+)
+
 func Parse(ctx context.Context, r io.Reader) (*Meta, error) {
 	doc, err := html.Parse(r)
 	if err != nil {
 		return nil, fmt.Errorf("html.Parse: %w", err)
 	}
 
-	var m Meta
+	p := parser{ranks: map[any]source{}}
 
-	if err := traverse(ctx, doc, &m); err != nil {
+	if err := p.traverse(ctx, doc); err != nil {
 		return nil, fmt.Errorf("traverse: %w", err)
 	}
 
-	return &m, nil
+	if p.published != "" {
+		p.m.Published = parseTime(p.published)
+	}
+
+	return &p.m, nil
+}
+
+// Resolve makes the URLs of m absolute, relative to the URL of the page.
+func (m *Meta) Resolve(base *url.URL) {
+	resolve := func(ref string) string {
+		u, err := base.Parse(ref)
+		if err != nil {
+			return ref
+		}
+		return u.String()
+	}
+
+	for i := range m.Images {
+		m.Images[i] = resolve(m.Images[i])
+	}
+	if m.Canonical != "" {
+		m.Canonical = resolve(m.Canonical)
+	}
+	if m.Favicon != "" {
+		m.Favicon = resolve(m.Favicon)
+	}
 }
 
-func traverse(ctx context.Context, n *html.Node, m *Meta) error {
-	if n.Type == html.ElementNode {
+type parser struct {
+	m Meta
+	// ranks are the sources of the fields set so far, by the field pointer.
+	ranks     map[any]source
+	published string
+}
+
+// set sets the field to v unless it is set from a greater source.
+func (p *parser) set(field *string, s source, v string) {
+	v = strings.TrimSpace(v)
+	if v == "" || p.ranks[field] >= s {
+		return
+	}
+
+	*field = v
+	p.ranks[field] = s
+}
+
+// addImage adds the image of a source to the ones of the same source, or
+// replaces the images of a lesser source.
+func (p *parser) addImage(s source, v string) {
+	v = strings.TrimSpace(v)
+	rank := p.ranks[&p.m.Images]
+	if v == "" || rank > s {
+		return
+	}
+
+	if rank < s {
+		p.m.Images = nil
+		p.ranks[&p.m.Images] = s
+	}
+	for _, img := range p.m.Images {
+		if img == v {
+			return
+		}
+	}
+	p.m.Images = append(p.m.Images, v)
+}
+
+func (p *parser) traverse(ctx context.Context, n *html.Node) error {
+	if n.Type == html.ElementNode && n.Namespace == "" {
 		switch n.Data {
 		case "meta":
-			parseMeta(m, n)
+			p.parseMeta(n)
+		case "link":
+			p.parseLink(n)
 		case "title":
-			if n.Parent.Data == "head" {
-				m.Title = n.FirstChild.Data
+			// the titles of inline SVG images are in the svg namespace
+			if n.FirstChild != nil {
+				p.set(&p.m.Title, sourceHTML, n.FirstChild.Data)
+			}
+		case "script":
+			if strings.EqualFold(attr(n, "type"), "application/ld+json") && n.FirstChild != nil {
+				p.parseJSONLD(n.FirstChild.Data)
 			}
 		default:
 		}
 	}
 
-	done := m.Title != "" && len(m.Tags) > 0 && m.Description != ""
-	if done {
-		return nil
-	}
-
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		if err := traverse(ctx, c, m); err != nil {
+		if err := p.traverse(ctx, c); err != nil {
 			return err
 		}
 	}
@@ -61,39 +148,101 @@ func traverse(ctx context.Context, n *html.Node, m *Meta) error {
 	return nil
 }
 
-func parseMeta(m *Meta, n *html.Node) {
-	if len(n.Attr) < 2 {
+func (p *parser) parseMeta(n *html.Node) {
+	// OpenGraph uses property, but some pages use name for it as well
+	key := strings.ToLower(attr(n, "property"))
+	if key == "" {
+		key = strings.ToLower(attr(n, "name"))
+	}
+	content := attr(n, "content")
+	if key == "" || content == "" {
 		return
 	}
 
-	var content string
+	switch key {
+	case "og:title":
+		p.set(&p.m.Title, sourceOpenGraph, content)
+	case "og:description":
+		p.set(&p.m.Description, sourceOpenGraph, content)
+	case "og:image", "og:image:url", "og:image:secure_url":
+		p.addImage(sourceOpenGraph, content)
+	case "og:site_name":
+		p.set(&p.m.SiteName, sourceOpenGraph, content)
+	case "og:url":
+		p.set(&p.m.Canonical, sourceOpenGraph, content)
+	case "og:type":
+		p.set(&p.m.Type, sourceOpenGraph, content)
+	case "article:author":
+		p.set(&p.m.Author, sourceOpenGraph, content)
+	case "article:published_time":
+		p.set(&p.published, sourceOpenGraph, content)
+
+	case "twitter:title":
+		p.set(&p.m.Title, sourceTwitter, content)
+	case "twitter:description":
+		p.set(&p.m.Description, sourceTwitter, content)
+	case "twitter:image", "twitter:image:src":
+		p.addImage(sourceTwitter, content)
+	case "twitter:creator":
+		p.set(&p.m.Author, sourceTwitter, content)
+
+	case "description":
+		p.set(&p.m.Description, sourceHTML, content)
+	case "author":
+		p.set(&p.m.Author, sourceHTML, content)
+	case "keywords":
+		for _, tag := range strings.Split(content, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				p.m.Tags = append(p.m.Tags, tag)
+			}
+		}
+	default:
+	}
+}
 
-	var (
-		isKeywords    bool
-		isDescription bool
-	)
+func (p *parser) parseLink(n *html.Node) {
+	href := attr(n, "href")
+	if href == "" {
+		return
+	}
 
-	for _, attr := range n.Attr {
-		switch {
-		case attr.Key == "name" && strings.ToLower(attr.Val) == "keywords":
-			isKeywords = true
-		case attr.Key == "name" && strings.ToLower(attr.Val) == "description":
-			isDescription = true
-		case attr.Key == "content":
-			content = attr.Val
+	for _, rel := range strings.Fields(strings.ToLower(attr(n, "rel"))) {
+		switch rel {
+		case "canonical":
+			p.set(&p.m.Canonical, sourceHTML, href)
+		case "icon":
+			// "shortcut icon" is icon as well
+			p.set(&p.m.Favicon, sourceHTML, href)
+		case "image_src":
+			p.addImage(sourceHTML, href)
 		}
 	}
+}
 
-	switch {
-	case isDescription:
-		m.Description = content
-	case isKeywords:
-		tags := strings.Split(content, ",")
-		for idx1 := range tags {
-			tags[idx1] = strings.TrimSpace(tags[idx1])
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
 		}
+	}
 
-		m.Tags = append(m.Tags, tags...)
-	default:
+	return ""
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+func parseTime(v string) *time.Time {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return &t
+		}
 	}
+
+	return nil
 }
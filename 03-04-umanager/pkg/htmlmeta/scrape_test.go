@@ -2,8 +2,11 @@ package htmlmeta
 
 import (
 	"context"
+	"net/url"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestParse(t *testing.T) {
@@ -73,3 +76,116 @@ func TestParse(t *testing.T) {
 		)
 	}
 }
+
+func TestParseSources(t *testing.T) {
+	published := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected Meta
+	}{
+		{
+			name: "test_opengraph_over_other_sources",
+			input: `<html><head>
+						<title>HTML Title</title>
+						<meta name="description" content="HTML Description">
+						<meta property="og:title" content="OG Title">
+						<meta property="og:description" content="OG Description">
+						<meta property="og:image" content="https://example.com/a.png">
+						<meta property="og:image" content="https://example.com/b.png">
+						<meta property="og:site_name" content="Example">
+						<meta property="og:type" content="article">
+						<meta property="og:url" content="https://example.com/post">
+						<meta name="twitter:title" content="Twitter Title">
+						<meta name="twitter:image" content="https://example.com/twitter.png">
+						<meta name="twitter:creator" content="@alice">
+						<meta property="article:published_time" content="2024-03-01T10:00:00Z">
+					</head></html>`,
+			expected: Meta{
+				Title:       "OG Title",
+				Description: "OG Description",
+				Images:      []string{"https://example.com/a.png", "https://example.com/b.png"},
+				SiteName:    "Example",
+				Author:      "@alice",
+				Published:   &published,
+				Canonical:   "https://example.com/post",
+				Type:        "article",
+			},
+		},
+		{
+			name: "test_twitter_over_json_ld",
+			input: `<html><head>
+						<meta name="twitter:title" content="Twitter Title">
+						<meta name="twitter:image:src" content="https://example.com/twitter.png">
+						<script type="application/ld+json">
+							{"@type": "NewsArticle", "headline": "LD Headline", "image": ["https://example.com/ld.png"]}
+						</script>
+					</head></html>`,
+			expected: Meta{
+				Title:  "Twitter Title",
+				Images: []string{"https://example.com/twitter.png"},
+				Type:   "NewsArticle",
+			},
+		},
+		{
+			name: "test_json_ld_over_html",
+			input: `<html><head>
+						<title>HTML Title</title>
+						<meta name="author" content="Someone">
+						<link rel="canonical" href="/post">
+						<link rel="shortcut icon" href="/favicon.ico">
+						<script type="application/ld+json">
+							{"@context": "https://schema.org", "@graph": [
+								{"@type": "Organization", "name": "Example Inc", "description": "Not the page"},
+								{"@type": "WebSite", "name": "Example"},
+								{"@type": ["BlogPosting"], "headline": "LD Headline",
+								 "author": [{"@type": "Person", "name": "Alice"}],
+								 "datePublished": "2024-03-01T10:00:00Z",
+								 "image": {"@type": "ImageObject", "url": "/ld.png"}}
+							]}
+						</script>
+					</head></html>`,
+			expected: Meta{
+				Title:     "LD Headline",
+				Images:    []string{"https://example.com/ld.png"},
+				SiteName:  "Example",
+				Author:    "Alice",
+				Published: &published,
+				Canonical: "https://example.com/post",
+				Favicon:   "https://example.com/favicon.ico",
+				Type:      "BlogPosting",
+			},
+		},
+		{
+			name: "test_title_outside_head_and_svg_title",
+			input: `<html><body>
+						<svg><title>Icon</title></svg>
+						<title>Body Title</title>
+						<script type="application/ld+json">not json</script>
+					</body></html>`,
+			expected: Meta{
+				Title: "Body Title",
+			},
+		},
+	}
+
+	base, err := url.Parse("https://example.com/blog/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(context.Background(), strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got.Resolve(base)
+
+			if !reflect.DeepEqual(*got, tt.expected) {
+				t.Errorf("Parse() = %+v, want %+v", *got, tt.expected)
+			}
+		})
+	}
+}
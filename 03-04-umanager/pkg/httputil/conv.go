@@ -0,0 +1,77 @@
+package httputil
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/api/apiv1"
+)
+
+func ConvertHTTPToErrorCode(code int) apiv1.ErrorCode {
+	switch code {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType,
+		http.StatusMethodNotAllowed, http.StatusRequestTimeout:
+		return apiv1.BadRequest
+	case http.StatusUnauthorized:
+		return apiv1.Unauthorized
+	case http.StatusForbidden:
+		return apiv1.Forbidden
+	case http.StatusNotFound:
+		return apiv1.NotFound
+	case http.StatusConflict:
+		return apiv1.Conflict
+	case http.StatusTooManyRequests:
+		return apiv1.TooManyRequests
+	case http.StatusServiceUnavailable:
+		return apiv1.ServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return apiv1.GatewayTimeout
+	}
+	return apiv1.InternalServerError
+}
+
+func ConvertGRPCToErrorCode(grpcCode codes.Code) apiv1.ErrorCode {
+	return ConvertHTTPToErrorCode(ConvertGRPCCodeToHTTP(grpcCode))
+}
+
+func ConvertGRPCCodeToHTTP(grpcCode codes.Code) int {
+	switch grpcCode {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return http.StatusRequestTimeout
+	case codes.Unknown:
+		return http.StatusInternalServerError
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Aborted:
+		return http.StatusConflict
+	case codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Internal:
+		return http.StatusInternalServerError
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DataLoss:
+		return http.StatusInternalServerError
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,41 @@
+package httputil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/api/apiv1"
+)
+
+// MaxBodyBytes limits the size of request bodies.
+const MaxBodyBytes = 64_000
+
+func MarshalResponse(w http.ResponseWriter, status int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "%s", data)
+}
+
+// WriteError writes an apiv1.Error with the given HTTP status. The ID set by
+// the request ID middleware is included so that clients can report it.
+func WriteError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	res := apiv1.Error{Code: ConvertHTTPToErrorCode(status)}
+	if message != "" {
+		res.Message = &message
+	}
+	if id := middleware.GetReqID(r.Context()); id != "" {
+		res.RequestId = &id
+	}
+
+	MarshalResponse(w, status, res)
+}
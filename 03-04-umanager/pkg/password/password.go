@@ -0,0 +1,133 @@
+package password
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+var (
+	ErrMismatch            = errors.New("password mismatch")
+	ErrInvalidHash         = errors.New("invalid password hash")
+	ErrUnknownAlgorithm    = errors.New("unknown password hash algorithm")
+	ErrIncompatibleVersion = errors.New("incompatible password hash version")
+)
+
+// Hasher hashes passwords into self-describing strings: the algorithm and its
+// parameters are stored in the hash itself, so they can change over time.
+type Hasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encoded string) error
+	NeedsRehash(encoded string) bool
+}
+
+// Config selects the algorithm used for new hashes and its parameters.
+type Config struct {
+	Algorithm  string
+	Argon2id   Argon2idParams
+	BcryptCost int
+}
+
+// New returns a Hasher that produces hashes with the configured algorithm
+// and verifies hashes produced by any supported algorithm.
+func New(cfg Config) (*Manager, error) {
+	argon := NewArgon2id(cfg.Argon2id)
+	bcr := NewBcrypt(cfg.BcryptCost)
+
+	m := &Manager{
+		hashers: map[string]Hasher{
+			AlgorithmArgon2id: argon,
+			AlgorithmBcrypt:   bcr,
+		},
+	}
+
+	primary, ok := m.hashers[cfg.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, cfg.Algorithm)
+	}
+
+	m.algorithm = cfg.Algorithm
+	m.primary = primary
+
+	return m, nil
+}
+
+var _ Hasher = (*Manager)(nil)
+
+type Manager struct {
+	algorithm string
+	primary   Hasher
+	hashers   map[string]Hasher
+}
+
+func (m *Manager) Hash(password string) (string, error) {
+	return m.primary.Hash(password)
+}
+
+// Verify checks password against encoded. Passwords stored in plain text
+// before hashing was introduced are accepted as well, NeedsRehash reports
+// them so that they are hashed on the first successful login.
+func (m *Manager) Verify(password, encoded string) error {
+	if isLegacy(encoded) {
+		if subtle.ConstantTimeCompare([]byte(password), []byte(encoded)) != 1 {
+			return ErrMismatch
+		}
+		return nil
+	}
+
+	h, err := m.lookup(encoded)
+	if err != nil {
+		return err
+	}
+
+	return h.Verify(password, encoded)
+}
+
+// NeedsRehash reports whether encoded was produced by another algorithm or
+// with parameters different from the current ones.
+func (m *Manager) NeedsRehash(encoded string) bool {
+	algorithm, err := Algorithm(encoded)
+	if err != nil || algorithm != m.algorithm {
+		return true
+	}
+
+	return m.primary.NeedsRehash(encoded)
+}
+
+func (m *Manager) lookup(encoded string) (Hasher, error) {
+	algorithm, err := Algorithm(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	h, ok := m.hashers[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, algorithm)
+	}
+
+	return h, nil
+}
+
+// isLegacy reports whether encoded is a plain text password: every supported
+// hash starts with "$".
+func isLegacy(encoded string) bool {
+	return encoded != "" && !strings.HasPrefix(encoded, "$")
+}
+
+// Algorithm returns the name of the algorithm encoded hash was produced with.
+func Algorithm(encoded string) (string, error) {
+	switch {
+	case strings.HasPrefix(encoded, "$"+AlgorithmArgon2id+"$"):
+		return AlgorithmArgon2id, nil
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		return AlgorithmBcrypt, nil
+	default:
+		return "", ErrInvalidHash
+	}
+}
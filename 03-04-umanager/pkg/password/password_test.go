@@ -0,0 +1,194 @@
+package password
+
+import (
+	"errors"
+	"testing"
+)
+
+var testArgon2idParams = Argon2idParams{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestManager(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+	}{
+		{
+			name:      "test_argon2id",
+			algorithm: AlgorithmArgon2id,
+		},
+		{
+			name:      "test_bcrypt",
+			algorithm: AlgorithmBcrypt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				m, err := New(Config{Algorithm: tt.algorithm, Argon2id: testArgon2idParams, BcryptCost: 4})
+				if err != nil {
+					t.Fatalf("New() error = %v", err)
+				}
+
+				hash, err := m.Hash("secret")
+				if err != nil {
+					t.Fatalf("Hash() error = %v", err)
+				}
+
+				if algorithm, _ := Algorithm(hash); algorithm != tt.algorithm {
+					t.Errorf("Algorithm() got = %v, want %v", algorithm, tt.algorithm)
+				}
+
+				if err := m.Verify("secret", hash); err != nil {
+					t.Errorf("Verify() error = %v", err)
+				}
+
+				if err := m.Verify("wrong", hash); !errors.Is(err, ErrMismatch) {
+					t.Errorf("Verify() error = %v, want %v", err, ErrMismatch)
+				}
+
+				if m.NeedsRehash(hash) {
+					t.Errorf("NeedsRehash() got = true, want false")
+				}
+			},
+		)
+	}
+}
+
+func TestManager_NeedsRehash(t *testing.T) {
+	old, err := New(Config{Algorithm: AlgorithmBcrypt, BcryptCost: 4})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	hash, err := old.Hash("secret")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	stronger := testArgon2idParams
+	stronger.Iterations++
+
+	tests := []struct {
+		name string
+		cfg  Config
+		hash string
+		want bool
+	}{
+		{
+			name: "test_same_params",
+			cfg:  Config{Algorithm: AlgorithmBcrypt, BcryptCost: 4},
+			hash: hash,
+			want: false,
+		},
+		{
+			name: "test_bcrypt_cost_changed",
+			cfg:  Config{Algorithm: AlgorithmBcrypt, BcryptCost: 5},
+			hash: hash,
+			want: true,
+		},
+		{
+			name: "test_algorithm_changed",
+			cfg:  Config{Algorithm: AlgorithmArgon2id, Argon2id: testArgon2idParams},
+			hash: hash,
+			want: true,
+		},
+		{
+			name: "test_argon2id_params_changed",
+			cfg:  Config{Algorithm: AlgorithmArgon2id, Argon2id: stronger},
+			hash: mustHash(t, NewArgon2id(testArgon2idParams), "secret"),
+			want: true,
+		},
+		{
+			name: "test_plaintext",
+			cfg:  Config{Algorithm: AlgorithmArgon2id, Argon2id: testArgon2idParams},
+			hash: "secret",
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				m, err := New(tt.cfg)
+				if err != nil {
+					t.Fatalf("New() error = %v", err)
+				}
+
+				if err := m.Verify("secret", tt.hash); err != nil && !errors.Is(err, ErrInvalidHash) {
+					t.Errorf("Verify() error = %v", err)
+				}
+
+				if got := m.NeedsRehash(tt.hash); got != tt.want {
+					t.Errorf("NeedsRehash() got = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestManager_VerifyLegacy(t *testing.T) {
+	m, err := New(Config{Algorithm: AlgorithmArgon2id, Argon2id: testArgon2idParams})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		encoded  string
+		want     error
+	}{
+		{
+			name:     "test_plaintext_matches",
+			password: "secret",
+			encoded:  "secret",
+			want:     nil,
+		},
+		{
+			name:     "test_plaintext_mismatch",
+			password: "wrong",
+			encoded:  "secret",
+			want:     ErrMismatch,
+		},
+		{
+			name:     "test_empty_is_not_plaintext",
+			password: "",
+			encoded:  "",
+			want:     ErrInvalidHash,
+		},
+		{
+			name:     "test_unknown_hash_is_not_plaintext",
+			password: "$1$secret",
+			encoded:  "$1$secret",
+			want:     ErrInvalidHash,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if err := m.Verify(tt.password, tt.encoded); !errors.Is(err, tt.want) {
+					t.Errorf("Verify() error = %v, want %v", err, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func mustHash(t *testing.T, h Hasher, password string) string {
+	t.Helper()
+
+	hash, err := h.Hash(password)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	return hash
+}
@@ -27,7 +27,9 @@ type LinkServiceClient interface {
 	GetLinkByUserID(ctx context.Context, in *GetLinksByUserId, opts ...grpc.CallOption) (*ListLinkResponse, error)
 	UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*Empty, error)
 	DeleteLink(ctx context.Context, in *DeleteLinkRequest, opts ...grpc.CallOption) (*Empty, error)
-	ListLinks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListLinkResponse, error)
+	ListLinks(ctx context.Context, in *ListLinksRequest, opts ...grpc.CallOption) (*ListLinkResponse, error)
+	SearchLinks(ctx context.Context, in *SearchLinksRequest, opts ...grpc.CallOption) (*ListLinkResponse, error)
+	RefreshLink(ctx context.Context, in *RefreshLinkRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type linkServiceClient struct {
@@ -83,7 +85,7 @@ func (c *linkServiceClient) DeleteLink(ctx context.Context, in *DeleteLinkReques
 	return out, nil
 }
 
-func (c *linkServiceClient) ListLinks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListLinkResponse, error) {
+func (c *linkServiceClient) ListLinks(ctx context.Context, in *ListLinksRequest, opts ...grpc.CallOption) (*ListLinkResponse, error) {
 	out := new(ListLinkResponse)
 	err := c.cc.Invoke(ctx, "/pb.LinkService/ListLinks", in, out, opts...)
 	if err != nil {
@@ -92,6 +94,24 @@ func (c *linkServiceClient) ListLinks(ctx context.Context, in *Empty, opts ...gr
 	return out, nil
 }
 
+func (c *linkServiceClient) SearchLinks(ctx context.Context, in *SearchLinksRequest, opts ...grpc.CallOption) (*ListLinkResponse, error) {
+	out := new(ListLinkResponse)
+	err := c.cc.Invoke(ctx, "/pb.LinkService/SearchLinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkServiceClient) RefreshLink(ctx context.Context, in *RefreshLinkRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/pb.LinkService/RefreshLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LinkServiceServer is the server API for LinkService service.
 // All implementations must embed UnimplementedLinkServiceServer
 // for forward compatibility
@@ -101,7 +121,9 @@ type LinkServiceServer interface {
 	GetLinkByUserID(context.Context, *GetLinksByUserId) (*ListLinkResponse, error)
 	UpdateLink(context.Context, *UpdateLinkRequest) (*Empty, error)
 	DeleteLink(context.Context, *DeleteLinkRequest) (*Empty, error)
-	ListLinks(context.Context, *Empty) (*ListLinkResponse, error)
+	ListLinks(context.Context, *ListLinksRequest) (*ListLinkResponse, error)
+	SearchLinks(context.Context, *SearchLinksRequest) (*ListLinkResponse, error)
+	RefreshLink(context.Context, *RefreshLinkRequest) (*Empty, error)
 	mustEmbedUnimplementedLinkServiceServer()
 }
 
@@ -124,9 +146,15 @@ func (UnimplementedLinkServiceServer) UpdateLink(context.Context, *UpdateLinkReq
 func (UnimplementedLinkServiceServer) DeleteLink(context.Context, *DeleteLinkRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteLink not implemented")
 }
-func (UnimplementedLinkServiceServer) ListLinks(context.Context, *Empty) (*ListLinkResponse, error) {
+func (UnimplementedLinkServiceServer) ListLinks(context.Context, *ListLinksRequest) (*ListLinkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListLinks not implemented")
 }
+func (UnimplementedLinkServiceServer) SearchLinks(context.Context, *SearchLinksRequest) (*ListLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchLinks not implemented")
+}
+func (UnimplementedLinkServiceServer) RefreshLink(context.Context, *RefreshLinkRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshLink not implemented")
+}
 func (UnimplementedLinkServiceServer) mustEmbedUnimplementedLinkServiceServer() {}
 
 // UnsafeLinkServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -231,7 +259,7 @@ func _LinkService_DeleteLink_Handler(srv interface{}, ctx context.Context, dec f
 }
 
 func _LinkService_ListLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ListLinksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -243,7 +271,43 @@ func _LinkService_ListLinks_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: "/pb.LinkService/ListLinks",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LinkServiceServer).ListLinks(ctx, req.(*Empty))
+		return srv.(LinkServiceServer).ListLinks(ctx, req.(*ListLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkService_SearchLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkServiceServer).SearchLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.LinkService/SearchLinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkServiceServer).SearchLinks(ctx, req.(*SearchLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkService_RefreshLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkServiceServer).RefreshLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.LinkService/RefreshLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkServiceServer).RefreshLink(ctx, req.(*RefreshLinkRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -276,6 +340,14 @@ var LinkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListLinks",
 			Handler:    _LinkService_ListLinks_Handler,
 		},
+		{
+			MethodName: "SearchLinks",
+			Handler:    _LinkService_SearchLinks_Handler,
+		},
+		{
+			MethodName: "RefreshLink",
+			Handler:    _LinkService_RefreshLink_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "links.proto",
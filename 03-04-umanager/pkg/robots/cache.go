@@ -0,0 +1,107 @@
+package robots
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FetchFunc fetches the robots.txt of the site at origin, like
+// "https://example.com", and parses it.
+type FetchFunc func(ctx context.Context, origin string) (*Rules, error)
+
+// Cache keeps the Rules of the sites for TTL, so that robots.txt is not
+// fetched before every page.
+type Cache struct {
+	ttl   time.Duration
+	fetch FetchFunc
+
+	mu        sync.Mutex
+	sites     map[string]*site
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type site struct {
+	// ready is closed once the rules are fetched
+	ready   chan struct{}
+	rules   *Rules
+	err     error
+	expires time.Time
+}
+
+func NewCache(ttl time.Duration, fetch FetchFunc) *Cache {
+	return &Cache{
+		ttl:   ttl,
+		fetch: fetch,
+		sites: make(map[string]*site),
+		now:   time.Now,
+	}
+}
+
+// Get returns the Rules of the site at origin. They are fetched once for the
+// concurrent calls, and fetched again on the next call if fetching fails.
+func (c *Cache) Get(ctx context.Context, origin string) (*Rules, error) {
+	c.mu.Lock()
+	now := c.now()
+	c.sweep(now)
+
+	s, ok := c.sites[origin]
+	if ok && s.fetched() && now.After(s.expires) {
+		ok = false
+	}
+	if !ok {
+		s = &site{ready: make(chan struct{})}
+		c.sites[origin] = s
+		c.mu.Unlock()
+
+		c.load(ctx, origin, s)
+		return s.rules, s.err
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-s.ready:
+		return s.rules, s.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Cache) load(ctx context.Context, origin string, s *site) {
+	rules, err := c.fetch(ctx, origin)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s.rules, s.err = rules, err
+	s.expires = c.now().Add(c.ttl)
+	if err != nil && c.sites[origin] == s {
+		delete(c.sites, origin)
+	}
+	close(s.ready)
+}
+
+func (s *site) fetched() bool {
+	select {
+	case <-s.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// sweep forgets the expired sites, so that the cache does not grow with every
+// site ever seen. c.mu must be held.
+func (c *Cache) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	c.lastSweep = now
+
+	for origin, s := range c.sites {
+		if s.fetched() && now.After(s.expires) {
+			delete(c.sites, origin)
+		}
+	}
+}
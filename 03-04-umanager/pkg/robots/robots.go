@@ -0,0 +1,171 @@
+// Package robots reads the robots.txt files of the sites, as described by
+// RFC 9309, and caches them per site.
+package robots
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxSize is the number of bytes of a robots.txt file that are parsed.
+const MaxSize = 500 << 10
+
+// Rules are the rules of a robots.txt file for a crawler.
+type Rules struct {
+	rules []rule
+	// CrawlDelay is the delay between the requests asked by the site. It is
+	// not a part of RFC 9309, but is widely used.
+	CrawlDelay time.Duration
+}
+
+type rule struct {
+	allow   bool
+	pattern string
+}
+
+// AllowAll returns the Rules of a site without robots.txt.
+func AllowAll() *Rules {
+	return &Rules{}
+}
+
+// Allowed tells whether the crawler may fetch the path, with the query, of a
+// URL. The longest matching rule applies, and an allow rule wins a tie.
+func (r *Rules) Allowed(path string) bool {
+	if path == "/robots.txt" {
+		return true
+	}
+
+	allowed, longest := true, -1
+	for _, rl := range r.rules {
+		if !match(rl.pattern, path) {
+			continue
+		}
+		if len(rl.pattern) > longest || len(rl.pattern) == longest && rl.allow {
+			allowed, longest = rl.allow, len(rl.pattern)
+		}
+	}
+
+	return allowed
+}
+
+// group is a group of rules for the user agents.
+type group struct {
+	agents     []string
+	rules      []rule
+	crawlDelay time.Duration
+}
+
+// Parse reads robots.txt for the crawler with the product token agent, like
+// "umanager". The groups of the agent apply, or the groups of "*" if there
+// are none.
+func Parse(r io.Reader, agent string) (*Rules, error) {
+	var (
+		groups []*group
+		cur    *group
+		// a user-agent line after the rules starts a new group
+		inRules bool
+	)
+
+	scanner := bufio.NewScanner(io.LimitReader(r, MaxSize))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if cur == nil || inRules {
+				cur = &group{}
+				groups = append(groups, cur)
+				inRules = false
+			}
+			cur.agents = append(cur.agents, strings.ToLower(value))
+		case "allow", "disallow":
+			if cur == nil {
+				continue
+			}
+			inRules = true
+			// an empty disallow rule allows everything, as no rule does
+			if value != "" {
+				cur.rules = append(cur.rules, rule{allow: key == "allow", pattern: value})
+			}
+		case "crawl-delay":
+			if cur == nil {
+				continue
+			}
+			inRules = true
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				cur.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	rules := &Rules{}
+	if !merge(rules, groups, strings.ToLower(agent)) {
+		merge(rules, groups, "*")
+	}
+
+	return rules, nil
+}
+
+// merge adds the rules of the groups of agent to rules and tells whether
+// there are any.
+func merge(rules *Rules, groups []*group, agent string) bool {
+	found := false
+	for _, g := range groups {
+		for _, a := range g.agents {
+			if a != agent {
+				continue
+			}
+			found = true
+			rules.rules = append(rules.rules, g.rules...)
+			rules.CrawlDelay = max(rules.CrawlDelay, g.crawlDelay)
+			break
+		}
+	}
+
+	return found
+}
+
+// match tells whether the pattern of a rule matches the path. "*" in the
+// pattern matches any characters and a trailing "$" the end of the path.
+func match(pattern, path string) bool {
+	end := strings.HasSuffix(pattern, "$")
+	if end {
+		pattern = pattern[:len(pattern)-1]
+	}
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	pos := len(parts[0])
+
+	for i, part := range parts[1:] {
+		if end && i == len(parts)-2 {
+			// the last part is at the end of the path
+			return len(path)-pos >= len(part) && strings.HasSuffix(path, part)
+		}
+
+		j := strings.Index(path[pos:], part)
+		if j < 0 {
+			return false
+		}
+		pos += j + len(part)
+	}
+
+	return !end || pos == len(path)
+}
@@ -0,0 +1,139 @@
+package robots
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const robotsTxt = `# comments are ignored
+User-agent: *
+Disallow: /private
+Crawl-delay: 1
+
+User-agent: Umanager
+User-agent: other
+Disallow: /
+Allow: /public
+Allow: /*.html$
+Disallow: /public/drafts # not published
+Crawl-delay: 2.5
+
+Sitemap: https://example.com/sitemap.xml
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		agent   string
+		path    string
+		allowed bool
+	}{
+		{name: "test_agent_group_applies", agent: "umanager", path: "/about", allowed: false},
+		{name: "test_longest_rule_applies", agent: "umanager", path: "/public/page", allowed: true},
+		{name: "test_longer_disallow_wins", agent: "umanager", path: "/public/drafts/1", allowed: false},
+		{name: "test_wildcard_with_end", agent: "umanager", path: "/blog/post.html", allowed: true},
+		{name: "test_end_anchor", agent: "umanager", path: "/blog/post.html?page=2", allowed: false},
+		{name: "test_robots_txt_is_allowed", agent: "umanager", path: "/robots.txt", allowed: true},
+		{name: "test_star_group_for_other_agents", agent: "bot", path: "/about", allowed: true},
+		{name: "test_star_group_disallows", agent: "bot", path: "/private/1", allowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := Parse(strings.NewReader(robotsTxt), tt.agent)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if got := rules.Allowed(tt.path); got != tt.allowed {
+				t.Errorf("Allowed(%s) = %v, want %v", tt.path, got, tt.allowed)
+			}
+		})
+	}
+
+	rules, err := Parse(strings.NewReader(robotsTxt), "Umanager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules.CrawlDelay != 2500*time.Millisecond {
+		t.Errorf("CrawlDelay = %s, want 2.5s", rules.CrawlDelay)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "/fish", path: "/fish.html", want: true},
+		{pattern: "/fish", path: "/Fish", want: false},
+		{pattern: "/fish*", path: "/fishheads/yummy.html", want: true},
+		{pattern: "/*.php", path: "/folder/index.php?q=1", want: true},
+		{pattern: "/*.php$", path: "/folder/index.php?q=1", want: false},
+		{pattern: "/*.php$", path: "/index.php", want: true},
+		{pattern: "/fish*.php", path: "/fishheads/catfish.php?p", want: true},
+		{pattern: "/fish*.php", path: "/Fish.PHP", want: false},
+		{pattern: "/a$", path: "/a", want: true},
+		{pattern: "/a$", path: "/ab", want: false},
+		{pattern: "/*a*b$", path: "/xaxb", want: true},
+	}
+	for _, tt := range tests {
+		t.Run("test_"+tt.pattern+"_"+tt.path, func(t *testing.T) {
+			if got := match(tt.pattern, tt.path); got != tt.want {
+				t.Errorf("match(%s, %s) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheGet(t *testing.T) {
+	var fetches atomic.Int32
+	var fail atomic.Bool
+	fail.Store(true)
+	c := NewCache(time.Hour, func(ctx context.Context, origin string) (*Rules, error) {
+		fetches.Add(1)
+		if fail.Load() {
+			return nil, errors.New("connection refused")
+		}
+		time.Sleep(10 * time.Millisecond)
+		return AllowAll(), nil
+	})
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	if _, err := c.Get(context.Background(), "https://example.com"); err == nil {
+		t.Fatal("Get() error = nil")
+	}
+
+	// the error is not cached, and the concurrent calls share a fetch
+	fail.Store(false)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Get(context.Background(), "https://example.com"); err != nil {
+				t.Errorf("Get() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := fetches.Load(); got != 2 {
+		t.Errorf("fetches = %d, want 2", got)
+	}
+
+	c.mu.Lock()
+	now = now.Add(2 * time.Hour)
+	c.mu.Unlock()
+	if _, err := c.Get(context.Background(), "https://example.com"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := fetches.Load(); got != 3 {
+		t.Errorf("fetches = %d, want the expired rules fetched again", got)
+	}
+}
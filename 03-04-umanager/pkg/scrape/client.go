@@ -1,38 +1,386 @@
 package scrape
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"net"
 	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
 
 	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/htmlmeta"
+	"github.com/EfimVelichkin/3rd_module_GO/03-03-umanager/pkg/robots"
+)
+
+var (
+	ErrStatusCodeInvalid = errors.New("status code invalid")
+	// ErrForbidden is returned for the URLs that are not http or https, or
+	// that resolve to a private, loopback or link-local address.
+	ErrForbidden         = errors.New("url forbidden")
+	ErrTooManyRedirects  = errors.New("too many redirects")
+	ErrContentTypeDenied = errors.New("content type denied")
+	// ErrRobotsDisallowed is returned for the URLs that robots.txt of the
+	// site does not allow to fetch.
+	ErrRobotsDisallowed = errors.New("disallowed by robots.txt")
 )
 
-var client = http.DefaultClient
+// StatusError is returned for the responses with a status other than 200 OK.
+// It is ErrStatusCodeInvalid.
+type StatusError struct {
+	Code int
+	// URL is the URL of the response after the redirects.
+	URL string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %d", ErrStatusCodeInvalid, e.Code)
+}
+
+func (e *StatusError) Unwrap() error {
+	return ErrStatusCodeInvalid
+}
 
-var ErrStatusCodeInvalid = errors.New("status code invalid")
+type Config struct {
+	ConnectTimeout time.Duration
+	// ReadTimeout bounds the wait for the response headers and, along with
+	// ConnectTimeout, the whole request.
+	ReadTimeout time.Duration
+	// MaxBodySize is the number of bytes of the page that are parsed. The
+	// metadata is in the head of the page, so the rest is not read.
+	MaxBodySize  int64
+	MaxRedirects int
+	// ContentTypes are the media types of the pages that are parsed.
+	ContentTypes []string
+	// AllowedHosts and AllowedNetworks may be fetched although they are
+	// private.
+	AllowedHosts    []string
+	AllowedNetworks []netip.Prefix
+	// UserAgent is the User-Agent of the requests, like "umanager/1.0". Its
+	// product token, the part before "/", is the user agent in robots.txt.
+	UserAgent string
+	// ContactURL is added to the User-Agent, so that the sites can reach
+	// the owner of the crawler.
+	ContactURL string
+	// RobotsTTL is how long robots.txt of a site is cached.
+	RobotsTTL time.Duration
+	// MaxCrawlDelay caps the Crawl-delay of the sites, so that a site cannot
+	// hold the link updater for long. Zero ignores Crawl-delay.
+	MaxCrawlDelay time.Duration
+}
 
+// DefaultConfig is the Config of the package level Parse.
+func DefaultConfig() Config {
+	return Config{
+		ConnectTimeout: 5 * time.Second,
+		ReadTimeout:    10 * time.Second,
+		MaxBodySize:    2 << 20,
+		MaxRedirects:   5,
+		ContentTypes:   []string{"text/html", "application/xhtml+xml"},
+		UserAgent:      "umanager/1.0",
+		RobotsTTL:      24 * time.Hour,
+		MaxCrawlDelay:  time.Minute,
+	}
+}
+
+var defaultClient = New(DefaultConfig())
+
+// Parse fetches the page at url with the DefaultConfig and parses its
+// metadata.
 func Parse(ctx context.Context, url string) (*htmlmeta.Meta, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	return defaultClient.Parse(ctx, url)
+}
+
+// Client fetches the pages of the URLs saved by users, which cannot be
+// trusted: every address a host resolves to is checked before connecting, on
+// redirects as well, so that the internal services cannot be reached.
+type Client struct {
+	cfg       Config
+	client    *http.Client
+	dialer    *net.Dialer
+	robots    *robots.Cache
+	userAgent string
+	// agent is the product token of userAgent
+	agent string
+}
+
+func New(cfg Config) *Client {
+	c := &Client{
+		cfg:       cfg,
+		dialer:    &net.Dialer{Timeout: cfg.ConnectTimeout},
+		userAgent: cfg.UserAgent,
+	}
+	c.agent, _, _ = strings.Cut(cfg.UserAgent, "/")
+	if cfg.ContactURL != "" {
+		c.userAgent += " (+" + cfg.ContactURL + ")"
+	}
+	c.robots = robots.NewCache(cfg.RobotsTTL, c.fetchRobots)
+
+	c.client = &http.Client{
+		Timeout: cfg.ConnectTimeout + cfg.ReadTimeout,
+		Transport: &http.Transport{
+			// a proxy would resolve the hosts instead of dial
+			Proxy:                 nil,
+			DialContext:           c.dial,
+			TLSHandshakeTimeout:   cfg.ConnectTimeout,
+			ResponseHeaderTimeout: cfg.ReadTimeout,
+			MaxIdleConnsPerHost:   2,
+			IdleConnTimeout:       90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > cfg.MaxRedirects {
+				return ErrTooManyRedirects
+			}
+			if err := checkScheme(req.URL); err != nil {
+				return err
+			}
+			// waiting for the rules of a site while fetching them would
+			// never end
+			if req.Context().Value(robotsRequest{}) != nil {
+				return nil
+			}
+			return c.checkRobots(req.Context(), req.URL)
+		},
+	}
+
+	return c
+}
+
+// Page is a fetched page.
+type Page struct {
+	// URL is the URL of the page after the redirects.
+	URL  string
+	Meta *htmlmeta.Meta
+}
+
+func (c *Client) Parse(ctx context.Context, rawURL string) (*htmlmeta.Meta, error) {
+	page, err := c.Fetch(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Meta, nil
+}
+
+// Fetch fetches the page at rawURL, following the redirects, and parses its
+// metadata.
+func (c *Client) Fetch(ctx context.Context, rawURL string) (*Page, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrForbidden, err)
+	}
+	if err := checkScheme(u); err != nil {
+		return nil, err
+	}
+	if err := c.checkRobots(ctx, u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("http NewRequestWithContext: %w", err)
 	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.1")
+	req.Header.Set("User-Agent", c.userAgent)
 
-	resp, err := client.Do(req)
+	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("http client Do: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ErrStatusCodeInvalid
+		return nil, &StatusError{Code: resp.StatusCode, URL: resp.Request.URL.String()}
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	body := bufio.NewReader(io.LimitReader(resp.Body, c.cfg.MaxBodySize))
+	if err := c.checkContentType(contentType, body); err != nil {
+		return nil, err
+	}
+
+	// the charset is taken from the BOM, the Content-Type or the meta tags
+	// of the page, in this order, and the page is decoded to UTF-8
+	decoded, err := charset.NewReader(body, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("charset NewReader: %w", err)
 	}
 
-	meta, err := htmlmeta.Parse(ctx, resp.Body)
+	meta, err := htmlmeta.Parse(ctx, decoded)
 	if err != nil {
 		return nil, fmt.Errorf("htmlmeta Parse: %w", err)
 	}
+	// the page may be redirected, so the URLs are relative to the last one
+	meta.Resolve(resp.Request.URL)
+
+	return &Page{URL: resp.Request.URL.String(), Meta: meta}, nil
+}
+
+// CrawlDelay returns the delay between the requests to the site of rawURL
+// asked by its robots.txt, up to MaxCrawlDelay.
+func (c *Client) CrawlDelay(ctx context.Context, rawURL string) (time.Duration, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrForbidden, err)
+	}
+	if err := checkScheme(u); err != nil {
+		return 0, err
+	}
+
+	rules, err := c.robots.Get(ctx, origin(u))
+	if err != nil {
+		return 0, fmt.Errorf("robots.txt: %w", err)
+	}
+
+	return min(rules.CrawlDelay, c.cfg.MaxCrawlDelay), nil
+}
+
+// checkRobots checks that robots.txt of the site allows to fetch u.
+func (c *Client) checkRobots(ctx context.Context, u *url.URL) error {
+	rules, err := c.robots.Get(ctx, origin(u))
+	if err != nil {
+		return fmt.Errorf("robots.txt: %w", err)
+	}
+	if !rules.Allowed(u.RequestURI()) {
+		return fmt.Errorf("%w: %s", ErrRobotsDisallowed, u)
+	}
+
+	return nil
+}
+
+// fetchRobots fetches robots.txt of the site at origin. A site without
+// robots.txt allows everything, while a failing one is asked again later.
+func (c *Client) fetchRobots(ctx context.Context, origin string) (*robots.Rules, error) {
+	ctx = context.WithValue(ctx, robotsRequest{}, true)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return nil, fmt.Errorf("http NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http client Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return robots.Parse(resp.Body, c.agent)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return robots.AllowAll(), nil
+	default:
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+}
+
+// robotsRequest marks the context of the requests of robots.txt.
+type robotsRequest struct{}
+
+// origin returns the scheme and the host of u, which robots.txt applies to.
+func origin(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
+
+// checkContentType checks the media type of the response, sniffed from the
+// body if the server does not tell it.
+func (c *Client) checkContentType(header string, body *bufio.Reader) error {
+	if header == "" {
+		// Peek fails on the bodies shorter than 512 bytes, but returns them
+		sniff, _ := body.Peek(512)
+		header = http.DetectContentType(sniff)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrContentTypeDenied, header)
+	}
+	if !slices.Contains(c.cfg.ContentTypes, mediaType) {
+		return fmt.Errorf("%w: %s", ErrContentTypeDenied, mediaType)
+	}
+
+	return nil
+}
+
+// dial resolves the host of addr and connects to one of its addresses if
+// none of them is forbidden.
+func (c *Client) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(c.cfg.AllowedHosts, host) {
+		for _, ip := range ips {
+			if !c.allowed(ip) {
+				return nil, fmt.Errorf("%w: %s resolves to %s", ErrForbidden, host, ip)
+			}
+		}
+	}
+
+	var dialErr error
+	for _, ip := range ips {
+		conn, err := c.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = err
+	}
+
+	return nil, dialErr
+}
+
+// forbiddenNetworks are the special-purpose networks that are neither
+// private, loopback nor link-local by the netip checks.
+var forbiddenNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+func (c *Client) allowed(ip netip.Addr) bool {
+	ip = ip.Unmap()
+
+	for _, p := range c.cfg.AllowedNetworks {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+
+	for _, p := range forbiddenNetworks {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme %q", ErrForbidden, u.Scheme)
+	}
 
-	return meta, nil
+	return nil
 }
@@ -0,0 +1,252 @@
+package scrape
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientAllowed(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{ip: "127.0.0.1", want: false},
+		{ip: "::1", want: false},
+		{ip: "10.1.2.3", want: false},
+		{ip: "172.16.0.1", want: false},
+		{ip: "192.168.1.1", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "fe80::1", want: false},
+		{ip: "fd00::1", want: false},
+		{ip: "0.0.0.0", want: false},
+		{ip: "100.64.0.1", want: false},
+		{ip: "::ffff:127.0.0.1", want: false},
+		{ip: "::ffff:10.0.0.1", want: false},
+	}
+	c := New(DefaultConfig())
+	for _, tt := range tests {
+		t.Run("test_"+tt.ip, func(t *testing.T) {
+			if got := c.allowed(netip.MustParseAddr(tt.ip)); got != tt.want {
+				t.Errorf("allowed(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientParse(t *testing.T) {
+	page := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><title>Go</title></head><body></body></html>`))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", page)
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"title":"Go"}`))
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head>` + strings.Repeat("<meta>", 1000) + `<title>Go</title></head></html>`))
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	// the server listens on 127.0.0.1, so it is reached by the name of
+	// localhost to be allowed by host
+	localhost := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+	mux.HandleFunc("/escape", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, srv.URL+"/page", http.StatusFound)
+	})
+
+	loopback := []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+
+	tests := []struct {
+		name     string
+		url      string
+		hosts    []string
+		networks []netip.Prefix
+		title    string
+		err      error
+	}{
+		{
+			name: "test_loopback_is_forbidden",
+			url:  srv.URL + "/page",
+			err:  ErrForbidden,
+		},
+		{
+			name:     "test_allowed_network",
+			url:      srv.URL + "/page",
+			networks: loopback,
+			title:    "Go",
+		},
+		{
+			name:  "test_allowed_host",
+			url:   localhost + "/page",
+			hosts: []string{"localhost"},
+			title: "Go",
+		},
+		{
+			name:  "test_redirect_to_forbidden_address",
+			url:   localhost + "/escape",
+			hosts: []string{"localhost"},
+			err:   ErrForbidden,
+		},
+		{
+			name: "test_scheme_is_forbidden",
+			url:  "file:///etc/passwd",
+			err:  ErrForbidden,
+		},
+		{
+			name:     "test_too_many_redirects",
+			url:      srv.URL + "/loop",
+			networks: loopback,
+			err:      ErrTooManyRedirects,
+		},
+		{
+			name:     "test_content_type_denied",
+			url:      srv.URL + "/json",
+			networks: loopback,
+			err:      ErrContentTypeDenied,
+		},
+		{
+			name:     "test_status_not_ok",
+			url:      srv.URL + "/missing",
+			networks: loopback,
+			err:      ErrStatusCodeInvalid,
+		},
+		{
+			name:     "test_body_over_max_size_is_not_read",
+			url:      srv.URL + "/large",
+			networks: loopback,
+			title:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.MaxBodySize = 1024
+			cfg.AllowedHosts = tt.hosts
+			cfg.AllowedNetworks = tt.networks
+
+			meta, err := New(cfg).Parse(context.Background(), tt.url)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if meta.Title != tt.title {
+				t.Errorf("title = %q, want %q", meta.Title, tt.title)
+			}
+		})
+	}
+}
+
+func TestClientFetchRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>Go</title></head></html>`))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/missing", http.StatusMovedPermanently)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.AllowedNetworks = []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+	c := New(cfg)
+
+	page, err := c.Fetch(context.Background(), srv.URL+"/moved")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if page.URL != srv.URL+"/page" || page.Meta.Title != "Go" {
+		t.Errorf("page = %s %q, want %s/page \"Go\"", page.URL, page.Meta.Title, srv.URL)
+	}
+
+	_, err = c.Fetch(context.Background(), srv.URL+"/gone")
+	var status *StatusError
+	if !errors.As(err, &status) {
+		t.Fatalf("Fetch() error = %v, want StatusError", err)
+	}
+	if status.Code != http.StatusNotFound || status.URL != srv.URL+"/missing" {
+		t.Errorf("status error = %d %s, want 404 %s/missing", status.Code, status.URL, srv.URL)
+	}
+}
+
+func TestClientRobots(t *testing.T) {
+	const userAgent = "umanager/1.0 (+https://example.com/bot)"
+
+	mux := http.NewServeMux()
+	// the rules are fetched through a redirect on the same site
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/robots-v2.txt", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/robots-v2.txt", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("User-agent: umanager\nDisallow: /private\nCrawl-delay: 5\n"))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != userAgent {
+			t.Errorf("User-Agent = %q, want %q", got, userAgent)
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>Go</title></head></html>`))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/private/page", http.StatusFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.AllowedNetworks = []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+	cfg.ContactURL = "https://example.com/bot"
+	cfg.MaxCrawlDelay = 2 * time.Second
+	c := New(cfg)
+
+	tests := []struct {
+		name string
+		path string
+		err  error
+	}{
+		{name: "test_allowed_page", path: "/page"},
+		{name: "test_disallowed_page", path: "/private/page", err: ErrRobotsDisallowed},
+		{name: "test_redirect_to_disallowed_page", path: "/moved", err: ErrRobotsDisallowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Fetch(context.Background(), srv.URL+tt.path)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Fetch() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	delay, err := c.CrawlDelay(context.Background(), srv.URL+"/page")
+	if err != nil {
+		t.Fatalf("CrawlDelay() error = %v", err)
+	}
+	if delay != 2*time.Second {
+		t.Errorf("CrawlDelay() = %s, want it capped at 2s", delay)
+	}
+}
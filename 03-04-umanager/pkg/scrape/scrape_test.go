@@ -0,0 +1,76 @@
+package scrape
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestParseCharset(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		title       string
+	}{
+		{
+			name:        "test_cp1251_from_content_type",
+			contentType: "text/html; charset=windows-1251",
+			body:        "<html><head><title>\xcf\xf0\xe8\xe2\xe5\xf2, \xec\xe8\xf0</title></head></html>",
+			title:       "Привет, мир",
+		},
+		{
+			name:        "test_koi8r_from_meta_charset",
+			contentType: "text/html",
+			body:        `<html><head><meta charset="koi8-r"><title>` + "\xf3\xd3\xd9\xcc\xcb\xc9" + `</title></head></html>`,
+			title:       "Ссылки",
+		},
+		{
+			name:        "test_latin1_from_meta_http_equiv",
+			contentType: "text/html",
+			body: `<html><head><meta http-equiv="Content-Type" content="text/html; charset=iso-8859-1">` +
+				"<title>Caf\xe9 d\xe9j\xe0 vu</title></head></html>",
+			title: "Café déjà vu",
+		},
+		{
+			name:        "test_shift_jis_from_content_type",
+			contentType: "text/html; charset=Shift_JIS",
+			body:        "<html><head><title>\x93\xfa\x96{\x8c\xea</title></head></html>",
+			title:       "日本語",
+		},
+		{
+			name:        "test_bom_over_content_type",
+			contentType: "text/html; charset=windows-1251",
+			body:        "\xef\xbb\xbf<html><head><title>Привет</title></head></html>",
+			title:       "Привет",
+		},
+		{
+			name:        "test_utf8_without_charset",
+			contentType: "text/html",
+			body:        "<html><head><title>Привет</title></head></html>",
+			title:       "Привет",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			cfg := DefaultConfig()
+			cfg.AllowedNetworks = []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+
+			meta, err := New(cfg).Parse(context.Background(), srv.URL)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if meta.Title != tt.title {
+				t.Errorf("title = %q, want %q", meta.Title, tt.title)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/api/apiv1"
+)
+
+func (s *IntegrationTestSuite) TestAPIKeyHandlers() {
+	t := s.T()
+	err := CreateSchema(s.conf.UsersService.Postgres.ConnectionURL())
+	assert.NoError(t, err)
+
+	err = SignUp("keys-user", "secret")
+	assert.NoError(t, err)
+	token, err := Login("keys-user", "secret")
+	assert.NoError(t, err)
+
+	var created apiv1.ApiKeyCreated
+
+	t.Run("Create API Key", func(t *testing.T) {
+		var client http.Client
+
+		reqBody := `{"name": "ci", "scopes": ["links:read"]}`
+		req, err := http.NewRequest(http.MethodPost, mainURL+"api-keys", strings.NewReader(reqBody))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		defer resp.Body.Close()
+
+		resBody, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		err = json.Unmarshal(resBody, &created)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created.Key)
+		assert.True(t, strings.HasPrefix(created.Key, created.ApiKey.Prefix))
+	})
+
+	t.Run("List API Keys", func(t *testing.T) {
+		var client http.Client
+
+		req, err := http.NewRequest(http.MethodGet, mainURL+"api-keys", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		defer resp.Body.Close()
+
+		resBody, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		var keys []apiv1.ApiKey
+		err = json.Unmarshal(resBody, &keys)
+		assert.NoError(t, err)
+		assert.Len(t, keys, 1)
+		assert.Equal(t, "ci", keys[0].Name)
+	})
+
+	t.Run("Use API Key Out Of Scope", func(t *testing.T) {
+		var client http.Client
+
+		reqBody := `{"title": "main page", "url": "https://gb.ru/"}`
+		req, err := http.NewRequest(http.MethodPost, mainURL+"links", strings.NewReader(reqBody))
+		req.Header.Set("X-API-Key", created.Key)
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("Revoke API Key", func(t *testing.T) {
+		var client http.Client
+
+		req, err := http.NewRequest(http.MethodDelete, mainURL+"api-keys/"+created.ApiKey.Id, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		req, err = http.NewRequest(http.MethodGet, mainURL+"api-keys", nil)
+		req.Header.Set("Authorization", "Bearer "+created.Key)
+		assert.NoError(t, err)
+
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/api/apiv1"
+)
+
+const (
+	adminUsername = "root"
+	adminPassword = "root"
+)
+
+// SignUp creates a user through the api-gw.
+func SignUp(username, password string) error {
+	reqBody := fmt.Sprintf(`{"username": %q, "password": %q}`, username, password)
+	resp, err := http.Post(mainURL+"users", "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("sign up %s: %s", username, resp.Status)
+	}
+
+	return nil
+}
+
+// Login returns an access token of the user.
+func Login(username, password string) (string, error) {
+	reqBody := fmt.Sprintf(`{"username": %q, "password": %q}`, username, password)
+	resp, err := http.Post(mainURL+"auth/login", "application/json", strings.NewReader(reqBody))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("login %s: %s", username, resp.Status)
+	}
+
+	var tokens apiv1.TokenPair
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return "", err
+	}
+
+	return tokens.AccessToken, nil
+}
+
+// AdminToken returns an access token of the admin user, creating the user on first use.
+func AdminToken(connStr string) (string, error) {
+	if _, err := Login(adminUsername, adminPassword); err != nil {
+		if err := SignUp(adminUsername, adminPassword); err != nil {
+			return "", err
+		}
+	}
+
+	if err := GrantRole(connStr, adminUsername, "admin"); err != nil {
+		return "", err
+	}
+
+	return Login(adminUsername, adminPassword)
+}
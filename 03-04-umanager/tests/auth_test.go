@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/api/apiv1"
+)
+
+func (s *IntegrationTestSuite) TestAuthHandlers() {
+	t := s.T()
+	err := CreateSchema(s.conf.UsersService.Postgres.ConnectionURL())
+	assert.NoError(t, err)
+
+	var tokens apiv1.TokenPair
+
+	t.Run("Login", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		var client http.Client
+
+		reqBody := `{"username": "auth-user", "password": "secret"}`
+		req, err := http.NewRequest(http.MethodPost, mainURL+"users", strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		req, err = http.NewRequest(http.MethodPost, mainURL+"auth/login", strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		defer resp.Body.Close()
+
+		resBody, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		err = json.Unmarshal(resBody, &tokens)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.NotEmpty(t, tokens.RefreshToken)
+	})
+
+	t.Run("Login Bad", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		var client http.Client
+
+		reqBody := `{"username": "auth-user", "password": "wrong"}`
+		req, err := http.NewRequest(http.MethodPost, mainURL+"auth/login", strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		var client http.Client
+
+		reqBody := `{"refresh_token": "` + tokens.RefreshToken + `"}`
+		req, err := http.NewRequest(http.MethodPost, mainURL+"auth/refresh", strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		defer resp.Body.Close()
+
+		resBody, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		var rotated apiv1.TokenPair
+		err = json.Unmarshal(resBody, &rotated)
+		assert.NoError(t, err)
+		assert.NotEqual(t, tokens.RefreshToken, rotated.RefreshToken)
+
+		// the rotated token can not be used twice
+		req, err = http.NewRequest(http.MethodPost, mainURL+"auth/refresh", strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		tokens = rotated
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		var client http.Client
+
+		reqBody := `{"refresh_token": "` + tokens.RefreshToken + `"}`
+		req, err := http.NewRequest(http.MethodPost, mainURL+"auth/logout", strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		req, err = http.NewRequest(http.MethodPost, mainURL+"auth/refresh", strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
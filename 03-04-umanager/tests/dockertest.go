@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/ory/dockertest/v3"
 	"github.com/ory/dockertest/v3/docker"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -21,9 +23,10 @@ const (
 	dbPassword  = "postgres"
 	dbPort      = 5434
 
-	mongoImg  = "mongo"
-	mongoTag  = "6-jammy"
-	mongoPort = 27018
+	mongoImg        = "mongo"
+	mongoTag        = "6-jammy"
+	mongoPort       = 27018
+	mongoReplicaSet = "rs0"
 
 	rabbitImg  = "rabbitmq"
 	rabbitTag  = "3.13-management-alpine"
@@ -98,6 +101,8 @@ func StartMongo() (*dockertest.Pool, *dockertest.Resource) {
 		&dockertest.RunOptions{
 			Repository: mongoImg,
 			Tag:        mongoTag,
+			// links are created in transactions, which need a replica set
+			Cmd: []string{"--replSet", mongoReplicaSet},
 			PortBindings: map[docker.Port][]docker.PortBinding{
 				"27017/tcp": {
 					{HostIP: "localhost", HostPort: fmt.Sprintf("%d/tcp", mongoPort)},
@@ -116,7 +121,7 @@ func StartMongo() (*dockertest.Pool, *dockertest.Resource) {
 
 	if err = pool.Retry(func() error {
 		mongoURL := fmt.Sprintf("mongodb://localhost:%s", resource.GetPort("27017/tcp"))
-		client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoURL))
+		client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoURL).SetDirect(true))
 		if err != nil {
 			return err
 		}
@@ -127,7 +132,7 @@ func StartMongo() (*dockertest.Pool, *dockertest.Resource) {
 			return err
 		}
 
-		return nil
+		return initiateReplicaSet(client)
 	}); err != nil {
 		log.Fatalf("cannot not connect to container: %s", err)
 	}
@@ -183,6 +188,33 @@ func StartRabbit() (*dockertest.Pool, *dockertest.Resource) {
 	return pool, resource
 }
 
+// initiateReplicaSet makes the mongo container a single node replica set and
+// reports an error until the node becomes the primary.
+func initiateReplicaSet(client *mongo.Client) error {
+	admin := client.Database("admin")
+
+	err := admin.RunCommand(context.Background(), bson.D{{Key: "replSetInitiate", Value: bson.M{
+		"_id":     mongoReplicaSet,
+		"members": bson.A{bson.M{"_id": 0, "host": "localhost:27017"}},
+	}}}).Err()
+	var cmdErr mongo.CommandError
+	if err != nil && !(errors.As(err, &cmdErr) && cmdErr.Name == "AlreadyInitialized") {
+		return err
+	}
+
+	var hello struct {
+		IsWritablePrimary bool `bson:"isWritablePrimary"`
+	}
+	if err := admin.RunCommand(context.Background(), bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return err
+	}
+	if !hello.IsWritablePrimary {
+		return errors.New("mongo replica set has no primary yet")
+	}
+
+	return nil
+}
+
 func Stop(pool *dockertest.Pool, resource *dockertest.Resource) {
 	if err := pool.Purge(resource); err != nil {
 		fmt.Printf("Could not purge resource: %s\n", err)
@@ -16,8 +16,21 @@ import (
 
 func (s *IntegrationTestSuite) TestLinkHandlers() {
 	t := s.T()
+	err := CreateSchema(s.conf.UsersService.Postgres.ConnectionURL())
+	assert.NoError(t, err)
 
-	var linkID primitive.ObjectID
+	err = SignUp("links-owner", "secret")
+	assert.NoError(t, err)
+	ownerToken, err := Login("links-owner", "secret")
+	assert.NoError(t, err)
+
+	adminToken, err := AdminToken(s.conf.UsersService.Postgres.ConnectionURL())
+	assert.NoError(t, err)
+
+	var (
+		linkID  primitive.ObjectID
+		ownerID string
+	)
 
 	t.Run("Create Link", func(t *testing.T) {
 		if testing.Short() {
@@ -34,6 +47,7 @@ func (s *IntegrationTestSuite) TestLinkHandlers() {
 			]
 		}`
 		req, err := http.NewRequest(http.MethodPost, mainURL+"links", strings.NewReader(reqBody))
+		req.Header.Set("Authorization", "Bearer "+ownerToken)
 		req.Header.Set("Content-Type", "application/json")
 		assert.NoError(t, err)
 
@@ -50,6 +64,7 @@ func (s *IntegrationTestSuite) TestLinkHandlers() {
 		var client http.Client
 
 		req, err := http.NewRequest(http.MethodGet, mainURL+"links", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
 		assert.NoError(t, err)
 
 		resp, err := client.Do(req)
@@ -67,12 +82,59 @@ func (s *IntegrationTestSuite) TestLinkHandlers() {
 		assert.NoError(t, err)
 		assert.Equal(t, "https://gb.ru/", result.Links[0].URL)
 		linkID = result.Links[0].ID
+		ownerID = result.Links[0].UserID
+	})
+
+	t.Run("Search Links", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		search := func(token, query string) (int, []database.Link) {
+			var client http.Client
+
+			req, err := http.NewRequest(http.MethodGet, mainURL+"links?"+query, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			assert.NoError(t, err)
+
+			resp, err := client.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			var result struct {
+				Links []database.Link `json:"links"`
+			}
+			if resp.StatusCode == http.StatusOK {
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			}
+			return resp.StatusCode, result.Links
+		}
+
+		code, found := search(ownerToken, "user_id="+ownerID+"&tag=edu&tag=news")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, found, 1)
+
+		code, found = search(ownerToken, "user_id="+ownerID+"&tag=edu&tag=news&match=all")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, found)
+
+		code, found = search(adminToken, "q=MAIN")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, found, 1)
+
+		code, found = search(adminToken, "created_after=2000-01-01T00:00:00Z&created_before=2001-01-01T00:00:00Z")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Empty(t, found)
+
+		code, _ = search(ownerToken, "tag=edu")
+		assert.Equal(t, http.StatusForbidden, code)
 	})
 
 	t.Run("Read Link", func(t *testing.T) {
 		var client http.Client
 
 		req, err := http.NewRequest(http.MethodGet, mainURL+"links/"+linkID.Hex(), nil)
+		req.Header.Set("Authorization", "Bearer "+ownerToken)
 		assert.NoError(t, err)
 
 		resp, err := client.Do(req)
@@ -91,11 +153,37 @@ func (s *IntegrationTestSuite) TestLinkHandlers() {
 		assert.Equal(t, "https://gb.ru/", link.URL)
 	})
 
+	t.Run("Read Link Foreign", func(t *testing.T) {
+		var client http.Client
+
+		err := SignUp("links-stranger", "secret")
+		assert.NoError(t, err)
+		strangerToken, err := Login("links-stranger", "secret")
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodGet, mainURL+"links/"+linkID.Hex(), nil)
+		req.Header.Set("Authorization", "Bearer "+strangerToken)
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		req, err = http.NewRequest(http.MethodGet, mainURL+"links", nil)
+		req.Header.Set("Authorization", "Bearer "+strangerToken)
+		assert.NoError(t, err)
+
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
 	t.Run("Update Link", func(t *testing.T) {
 		var client http.Client
 
 		reqBody := fmt.Sprintf(`{"id": "%s", "url": "https://ya.ru"}`, linkID.Hex())
 		req, err := http.NewRequest(http.MethodPut, mainURL+"links/"+linkID.Hex(), strings.NewReader(reqBody))
+		req.Header.Set("Authorization", "Bearer "+ownerToken)
 		req.Header.Set("Content-Type", "application/json")
 		assert.NoError(t, err)
 
@@ -111,6 +199,7 @@ func (s *IntegrationTestSuite) TestLinkHandlers() {
 		assert.Equal(t, "", string(resBody))
 
 		req, err = http.NewRequest(http.MethodGet, mainURL+"links/"+linkID.Hex(), nil)
+		req.Header.Set("Authorization", "Bearer "+ownerToken)
 		assert.NoError(t, err)
 
 		resp, err = client.Do(req)
@@ -133,6 +222,7 @@ func (s *IntegrationTestSuite) TestLinkHandlers() {
 		var client http.Client
 
 		req, err := http.NewRequest(http.MethodDelete, mainURL+"links/"+linkID.Hex(), nil)
+		req.Header.Set("Authorization", "Bearer "+ownerToken)
 		assert.NoError(t, err)
 
 		resp, err := client.Do(req)
@@ -140,6 +230,7 @@ func (s *IntegrationTestSuite) TestLinkHandlers() {
 		assert.NoError(t, err)
 
 		req, err = http.NewRequest(http.MethodGet, mainURL+"links/"+linkID.Hex(), nil)
+		req.Header.Set("Authorization", "Bearer "+ownerToken)
 		assert.NoError(t, err)
 
 		resp, err = client.Do(req)
@@ -147,16 +238,30 @@ func (s *IntegrationTestSuite) TestLinkHandlers() {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Create Link Unauthenticated", func(t *testing.T) {
+		var client http.Client
+
+		reqBody := `{"title": "main page", "url": "https://gb.ru/"}`
+		req, err := http.NewRequest(http.MethodPost, mainURL+"links", strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
 	t.Run("Read Link Bad", func(t *testing.T) {
 		var client http.Client
 
 		req, err := http.NewRequest(http.MethodGet, mainURL+"links/bad-id-string", nil)
+		req.Header.Set("Authorization", "Bearer "+ownerToken)
 		req.Header.Set("Content-Type", "application/json")
 
 		assert.NoError(t, err)
 
 		resp, err := client.Do(req)
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 }
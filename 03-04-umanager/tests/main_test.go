@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"net"
+	"net/http"
 	"testing"
 	"time"
 
@@ -15,61 +16,71 @@ import (
 
 type IntegrationTestSuite struct {
 	suite.Suite
-	conf       config.Config
-	closer     *env.Closer
-	pgPool     *dockertest.Pool
-	pgRes      *dockertest.Resource
-	mongoPool  *dockertest.Pool
-	mongoRes   *dockertest.Resource
-	rabbitPool *dockertest.Pool
-	rabbitRes  *dockertest.Resource
+	conf      config.Config
+	closer    *env.Closer
+	cancel    context.CancelFunc
+	pgPool    *dockertest.Pool
+	pgRes     *dockertest.Resource
+	mongoPool *dockertest.Pool
+	mongoRes  *dockertest.Resource
 }
 
 func (s *IntegrationTestSuite) SetupSuite() {
 	// Prepare Containers with required resources
 	s.pgPool, s.pgRes = StartPG()
 	s.mongoPool, s.mongoRes = StartMongo()
-	s.rabbitPool, s.rabbitRes = StartRabbit()
 	SetupEnv()
 
 	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	s.cancel = cancel
 
 	e, c, err := env.Setup(ctx)
-	s.Assert().NoError(err)
+	s.Require().NoError(err)
 
 	s.conf = e.Config
 	s.closer = c
 
+	go e.Users.Health.Run(ctx)
+	go e.Links.Health.Run(ctx)
+	go e.Links.OutboxRelay.Run(ctx)
+
 	go func() {
-		lis, err := net.Listen("tcp", e.Config.LinksService.GRPCServer.Addr)
+		lis, err := net.Listen("tcp", e.Links.Config.LinksService.GRPCServer.Addr)
 		s.Assert().NoError(err)
 
-		err = e.LinksGRPCServer.Serve(lis)
+		err = e.Links.GRPCServer.Serve(lis)
 		s.Assert().NoError(err)
 	}()
 
 	go func() {
-		lis, err := net.Listen("tcp", e.Config.UsersService.GRPCServer.Addr)
+		lis, err := net.Listen("tcp", e.Users.Config.UsersService.GRPCServer.Addr)
 		s.Assert().NoError(err)
 
-		err = e.UsersGRPCServer.Serve(lis)
+		err = e.Users.GRPCServer.Serve(lis)
 		s.Assert().NoError(err)
 	}()
 
 	go func() {
-		defer e.APIGWHTTPServer.Close()
-		err := e.APIGWHTTPServer.ListenAndServe()
+		defer e.Gateway.HTTPServer.Close()
+		err := e.Gateway.HTTPServer.ListenAndServe()
 		s.Assert().NoError(err)
 	}()
 
-	time.Sleep(time.Second) // Wait all goroutimes are running
+	s.Require().Eventually(func() bool {
+		resp, err := http.Get(readyURL)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+
+		return resp.StatusCode == http.StatusOK
+	}, 30*time.Second, 100*time.Millisecond, "services are not ready")
 }
 
 func (s *IntegrationTestSuite) TearDownSuite() {
 	defer Stop(s.pgPool, s.pgRes)
 	defer Stop(s.mongoPool, s.mongoRes)
-	defer Stop(s.rabbitPool, s.rabbitRes)
+	s.cancel()
 	s.closer.Close(context.Background())
 }
 
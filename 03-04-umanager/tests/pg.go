@@ -20,11 +20,91 @@ func CreateSchema(connStr string) error {
 		id         UUID NOT NULL,
 		username   TEXT NOT NULL,
 		password   TEXT NOT NULL,
+		roles      TEXT[] NOT NULL DEFAULT '{user}',
 		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
 		updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
 
 		CONSTRAINT pk_users_idx PRIMARY KEY (id),
 		CONSTRAINT users_username_uniq_idx UNIQUE (username)
 	)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = usersDBConn.Exec(ctx, `
+	CREATE TABLE IF NOT EXISTS refresh_tokens
+	(
+		id          UUID NOT NULL,
+		user_id     UUID NOT NULL,
+		family_id   UUID NOT NULL,
+		token_hash  TEXT NOT NULL,
+		expires_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+		revoked_at  TIMESTAMP WITH TIME ZONE,
+		replaced_by UUID,
+		created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+		CONSTRAINT pk_refresh_tokens_idx PRIMARY KEY (id),
+		CONSTRAINT refresh_tokens_token_hash_uniq_idx UNIQUE (token_hash),
+		CONSTRAINT fk_refresh_tokens_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = usersDBConn.Exec(ctx, `
+	CREATE TABLE IF NOT EXISTS api_keys
+	(
+		id           UUID NOT NULL,
+		user_id      UUID NOT NULL,
+		name         TEXT NOT NULL,
+		prefix       TEXT NOT NULL,
+		key_hash     TEXT NOT NULL,
+		scopes       TEXT[] NOT NULL DEFAULT '{}',
+		expires_at   TIMESTAMP WITH TIME ZONE,
+		last_used_at TIMESTAMP WITH TIME ZONE,
+		created_at   TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+		CONSTRAINT pk_api_keys_idx PRIMARY KEY (id),
+		CONSTRAINT api_keys_key_hash_uniq_idx UNIQUE (key_hash),
+		CONSTRAINT fk_api_keys_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+	)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = usersDBConn.Exec(ctx, `
+	CREATE TABLE IF NOT EXISTS roles
+	(
+		name        TEXT NOT NULL,
+		permissions TEXT[] NOT NULL DEFAULT '{}',
+
+		CONSTRAINT pk_roles_idx PRIMARY KEY (name)
+	)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = usersDBConn.Exec(ctx, `
+	INSERT INTO roles (name, permissions)
+	VALUES
+		('user', '{users:read,users:write,links:read,links:write}'),
+		('admin', '{users:read,users:write,users:manage,links:read,links:write,links:manage}')
+	ON CONFLICT (name) DO NOTHING`)
+	return err
+}
+
+func GrantRole(connStr, username, role string) error {
+	ctx := context.TODO()
+
+	usersDBConn, err := pgxpool.Connect(ctx, connStr)
+	if err != nil {
+		return err
+	}
+	defer usersDBConn.Close()
+
+	_, err = usersDBConn.Exec(
+		ctx, `UPDATE users SET roles = array_append(roles, $2) WHERE username = $1 AND NOT $2 = ANY(roles)`,
+		username, role,
+	)
 	return err
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/internal/database"
+	"github.com/EfimVelichkin/3rd_module_GO/03-04-umanager/pkg/api/apiv1"
 )
 
 func (s *IntegrationTestSuite) TestUserHandlers() {
@@ -19,6 +20,9 @@ func (s *IntegrationTestSuite) TestUserHandlers() {
 	err := CreateSchema(s.conf.UsersService.Postgres.ConnectionURL())
 	assert.NoError(t, err)
 
+	adminToken, err := AdminToken(s.conf.UsersService.Postgres.ConnectionURL())
+	assert.NoError(t, err)
+
 	var userID uuid.UUID
 
 	t.Run("Create User", func(t *testing.T) {
@@ -38,6 +42,23 @@ func (s *IntegrationTestSuite) TestUserHandlers() {
 		assert.Equal(t, http.StatusCreated, resp.StatusCode)
 	})
 
+	t.Run("Create User Conflict", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		var client http.Client
+
+		reqBody := `{"username": "pavel", "password": "other"}`
+		req, err := http.NewRequest(http.MethodPost, mainURL+"users", strings.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
 	t.Run("List Users", func(t *testing.T) {
 		if testing.Short() {
 			t.Skip()
@@ -46,6 +67,7 @@ func (s *IntegrationTestSuite) TestUserHandlers() {
 		var client http.Client
 
 		req, err := http.NewRequest(http.MethodGet, mainURL+"users", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
 		assert.NoError(t, err)
 
 		resp, err := client.Do(req)
@@ -61,8 +83,97 @@ func (s *IntegrationTestSuite) TestUserHandlers() {
 		}
 		err = json.Unmarshal(resBody, &result)
 		assert.NoError(t, err)
-		assert.Equal(t, "pavel", result.Users[0].Username)
-		userID = result.Users[0].ID
+		for _, u := range result.Users {
+			if u.Username == "pavel" {
+				userID = u.ID
+			}
+		}
+		assert.NotEqual(t, uuid.Nil, userID)
+	})
+
+	t.Run("List Users Forbidden", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		var client http.Client
+
+		token, err := Login("pavel", "test")
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodGet, mainURL+"users", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		req, err = http.NewRequest(http.MethodGet, mainURL+"users/"+userID.String(), nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		assert.NoError(t, err)
+
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		reqBody := `{"roles": ["admin"]}`
+		req, err = http.NewRequest(http.MethodPut, mainURL+"users/"+userID.String(), strings.NewReader(reqBody))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		assert.NoError(t, err)
+
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("List Users Paged", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip()
+		}
+
+		var client http.Client
+
+		req, err := http.NewRequest(http.MethodGet, mainURL+"users?limit=1&sort=created_at", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		assert.NoError(t, err)
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		defer resp.Body.Close()
+
+		var first apiv1.UserPage
+		err = json.NewDecoder(resp.Body).Decode(&first)
+		assert.NoError(t, err)
+		assert.Len(t, first.Users, 1)
+		if !assert.NotNil(t, first.NextCursor) {
+			return
+		}
+
+		req, err = http.NewRequest(http.MethodGet, mainURL+"users?limit=1&cursor="+*first.NextCursor, nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		assert.NoError(t, err)
+
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		defer resp.Body.Close()
+
+		var second apiv1.UserPage
+		err = json.NewDecoder(resp.Body).Decode(&second)
+		assert.NoError(t, err)
+		assert.Len(t, second.Users, 1)
+		assert.NotEqual(t, first.Users[0].Id, second.Users[0].Id)
+
+		req, err = http.NewRequest(http.MethodGet, mainURL+"users?cursor=broken", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		assert.NoError(t, err)
+
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
 	t.Run("Read User", func(t *testing.T) {
@@ -73,6 +184,7 @@ func (s *IntegrationTestSuite) TestUserHandlers() {
 		var client http.Client
 
 		req, err := http.NewRequest(http.MethodGet, mainURL+"users/"+userID.String(), nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
 		assert.NoError(t, err)
 
 		resp, err := client.Do(req)
@@ -100,6 +212,7 @@ func (s *IntegrationTestSuite) TestUserHandlers() {
 
 		reqBody := fmt.Sprintf(`{"id": "%s", "username": "admin"}`, userID.String())
 		req, err := http.NewRequest(http.MethodPut, mainURL+"users/"+userID.String(), strings.NewReader(reqBody))
+		req.Header.Set("Authorization", "Bearer "+adminToken)
 		req.Header.Set("Content-Type", "application/json")
 		assert.NoError(t, err)
 
@@ -115,6 +228,7 @@ func (s *IntegrationTestSuite) TestUserHandlers() {
 		assert.Equal(t, "", string(resBody))
 
 		req, err = http.NewRequest(http.MethodGet, mainURL+"users/"+userID.String(), nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
 		assert.NoError(t, err)
 
 		resp, err = client.Do(req)
@@ -141,6 +255,7 @@ func (s *IntegrationTestSuite) TestUserHandlers() {
 		var client http.Client
 
 		req, err := http.NewRequest(http.MethodDelete, mainURL+"users/"+userID.String(), nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
 		assert.NoError(t, err)
 
 		resp, err := client.Do(req)
@@ -148,6 +263,7 @@ func (s *IntegrationTestSuite) TestUserHandlers() {
 		assert.NoError(t, err)
 
 		req, err = http.NewRequest(http.MethodGet, mainURL+"users/"+userID.String(), nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
 		assert.NoError(t, err)
 
 		resp, err = client.Do(req)
@@ -163,13 +279,14 @@ func (s *IntegrationTestSuite) TestUserHandlers() {
 		var client http.Client
 
 		req, err := http.NewRequest(http.MethodGet, mainURL+"users/bad-uuid-string", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
 		req.Header.Set("Content-Type", "application/json")
 
 		assert.NoError(t, err)
 
 		resp, err := client.Do(req)
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
 	t.Run("Create User Bad", func(t *testing.T) {